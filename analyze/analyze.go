@@ -0,0 +1,290 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package analyze classifies migration statements by whether they can lose data or block
+// writes to a table. The rules follow the online DDL of MySQL 8 with InnoDB and are conservative:
+// a statement that might need a table copy is reported as blocking.
+package analyze
+
+import (
+	"fmt"
+	"github.com/golangee/sql/ddl"
+	"strings"
+)
+
+// Severity tells, how harmful a statement can be.
+type Severity int
+
+const (
+	// Safe statements neither lose data nor block writes for longer than a moment.
+	Safe Severity = iota
+	// Blocking statements copy or validate the whole table, which locks it for a long time if it is large.
+	Blocking
+	// DataLoss statements can delete or change existing values.
+	DataLoss
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Safe:
+		return "safe"
+	case Blocking:
+		return "blocking"
+	case DataLoss:
+		return "data-losing"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// Reason explains why a statement is not safe.
+type Reason struct {
+	Severity Severity
+	// Message is a human-readable explanation, like "drops column Email and its values".
+	Message string
+}
+
+func (r Reason) String() string {
+	return r.Severity.String() + ": " + r.Message
+}
+
+// Finding is the analysis of a single statement.
+type Finding struct {
+	Statement ddl.Statement
+	// Reasons are empty for a safe statement.
+	Reasons []Reason
+}
+
+// Severity returns the highest severity of the reasons, which is Safe if there are none.
+func (f Finding) Severity() Severity {
+	result := Safe
+
+	for _, reason := range f.Reasons {
+		if reason.Severity > result {
+			result = reason.Severity
+		}
+	}
+
+	return result
+}
+
+// String describes the finding in a single line, like "line 3: DROP TABLE shop.Cart: data-losing: drops ...".
+func (f Finding) String() string {
+	reasons := make([]string, 0, len(f.Reasons))
+	for _, reason := range f.Reasons {
+		reasons = append(reasons, reason.String())
+	}
+
+	if len(reasons) == 0 {
+		reasons = append(reasons, Safe.String())
+	}
+
+	return fmt.Sprintf("line %d: %s: %s", f.Statement.Line, f.Statement, strings.Join(reasons, "; "))
+}
+
+// MaxSeverity returns the highest severity of all findings, e.g. to fail a build on DataLoss.
+func MaxSeverity(findings []Finding) Severity {
+	result := Safe
+
+	for _, finding := range findings {
+		if severity := finding.Severity(); severity > result {
+			result = severity
+		}
+	}
+
+	return result
+}
+
+// Statements analyzes the statements, which are applied in order to the given tables. The tables are not
+// changed. Returns a ddl.ApplyError, if a statement cannot be applied, because then it cannot be analyzed.
+func Statements(tables []ddl.Table, statements []ddl.Statement) ([]Finding, error) {
+	schema := ddl.Schema{Tables: tables}.Clone()
+	findings := make([]Finding, 0, len(statements))
+
+	for _, statement := range statements {
+		findings = append(findings, Finding{
+			Statement: statement,
+			Reasons:   statementReasons(schema.Tables, statement),
+		})
+
+		if err := schema.Apply("", &ddl.ParseResult{Statements: []ddl.Statement{statement}}); err != nil {
+			return nil, err
+		}
+	}
+
+	return findings, nil
+}
+
+// AlterStatements analyzes the statements, which are applied in order to the given table, like a
+// result of diff.Table.
+func AlterStatements(table ddl.Table, statements []ddl.AlterStatement) ([]Finding, error) {
+	wrapped := make([]ddl.Statement, 0, len(statements))
+	for _, statement := range statements {
+		wrapped = append(wrapped, ddl.Statement{Alter: statement})
+	}
+
+	return Statements([]ddl.Table{table}, wrapped)
+}
+
+// statementReasons returns the reasons of a statement, which is applied to the given tables.
+func statementReasons(tables []ddl.Table, statement ddl.Statement) []Reason {
+	switch {
+	case statement.Alter != nil:
+		table := findTable(tables, statement.Alter.SchemaName(), statement.Alter.TableName())
+		if table == nil {
+			return nil
+		}
+
+		return alterReasons(*table, statement.Alter)
+	case statement.Schema != nil:
+		if findTable(tables, statement.Schema.SchemaName(), statement.Schema.TableName()) == nil {
+			return nil
+		}
+
+		switch stmt := statement.Schema.(type) {
+		case ddl.DropTable:
+			return []Reason{{DataLoss, fmt.Sprintf("drops table %s and all of its rows", stmt.Table)}}
+		case ddl.TruncateTable:
+			return []Reason{{DataLoss, fmt.Sprintf("deletes all rows of table %s", stmt.Table)}}
+		}
+	}
+
+	// CREATE TABLE and RENAME TABLE only change metadata.
+	return nil
+}
+
+// alterReasons returns the reasons of a statement, which is applied to the given table.
+func alterReasons(table ddl.Table, statement ddl.AlterStatement) []Reason {
+	switch stmt := statement.(type) {
+	case ddl.AlterAddColumn:
+		return addColumnReasons(stmt.Column)
+	case ddl.AlterDropColumn:
+		return []Reason{{DataLoss, fmt.Sprintf("drops column %s and its values", stmt.Column)}}
+	case ddl.AlterModifyColumn:
+		return changeColumnReasons(table, stmt.Column.Name, stmt.Column, stmt.First || stmt.After != nil)
+	case ddl.AlterChangeColumn:
+		return changeColumnReasons(table, stmt.OldName, stmt.Column, stmt.First || stmt.After != nil)
+	case ddl.AlterAddIndex:
+		if stmt.Kind != "" {
+			name := stmt.Name
+			if name == "" {
+				name = stmt.Parts[0].Column
+			}
+
+			return []Reason{{Blocking, fmt.Sprintf("adds %s index %s, which blocks writes while it is built", stmt.Kind, name)}}
+		}
+	case ddl.AlterAddPrimaryKey:
+		return []Reason{{Blocking, "adds a PRIMARY KEY, which rebuilds the table"}}
+	case ddl.AlterDropPrimaryKey:
+		return []Reason{{Blocking, "drops the PRIMARY KEY, which copies the table"}}
+	case ddl.AlterAddForeignKey:
+		return []Reason{{Blocking, fmt.Sprintf("adds FOREIGN KEY %s, which copies the table to validate its rows, unless foreign_key_checks is disabled",
+			foreignKeyName(stmt.ForeignKey))}}
+	case ddl.AlterAddCheck:
+		return []Reason{{Blocking, "adds a CHECK constraint, which copies the table to validate its rows"}}
+	case ddl.AlterTableOptions:
+		return tableOptionReasons(stmt.Options)
+	}
+
+	// Renaming columns and dropping indexes and constraints only change metadata.
+	return nil
+}
+
+// tableOptionReasons returns the reasons of changed table options. The other options, like
+// the default character set of new columns or the COMMENT, only change metadata.
+func tableOptionReasons(options ddl.TableOptions) []Reason {
+	var result []Reason
+
+	if options.Engine != nil {
+		result = append(result, Reason{Blocking, fmt.Sprintf("changes the ENGINE to %s, which copies the table", *options.Engine)})
+	}
+
+	if options.RowFormat != nil {
+		result = append(result, Reason{Blocking, fmt.Sprintf("changes the ROW_FORMAT to %s, which rebuilds the table", *options.RowFormat)})
+	}
+
+	return result
+}
+
+func addColumnReasons(column ddl.Column) []Reason {
+	var result []Reason
+
+	if column.NotNull && column.Default == nil && !column.AutoIncrement && column.Generated == nil {
+		result = append(result, Reason{DataLoss, fmt.Sprintf(
+			"adds NOT NULL column %s without a default, so existing rows get an implicit value like 0 or ''", column.Name)})
+	}
+
+	if column.AutoIncrement {
+		result = append(result, Reason{Blocking, fmt.Sprintf("adds AUTO_INCREMENT column %s, which copies the table", column.Name)})
+	}
+
+	if column.Generated != nil && column.Generated.Storage == ddl.GeneratedStored {
+		result = append(result, Reason{Blocking, fmt.Sprintf("adds STORED generated column %s, which copies the table", column.Name)})
+	}
+
+	return result
+}
+
+// changeColumnReasons compares the new definition of a column with its current one in the table.
+func changeColumnReasons(table ddl.Table, name string, column ddl.Column, moved bool) []Reason {
+	oldColumn := table.Column(name)
+	if oldColumn == nil {
+		return nil
+	}
+
+	var result []Reason
+
+	if reason, changed := typeChange(column.Name, oldColumn.Type, column.Type); changed {
+		result = append(result, reason)
+	}
+
+	if column.NotNull && !oldColumn.NotNull {
+		if column.Default == nil {
+			result = append(result, Reason{DataLoss, fmt.Sprintf(
+				"makes column %s NOT NULL without a default, so existing NULL values are replaced or rejected", column.Name)})
+		} else {
+			result = append(result, Reason{Blocking, fmt.Sprintf("makes column %s NOT NULL, which rebuilds the table", column.Name)})
+		}
+	}
+
+	if column.Generated != nil && column.Generated.Storage == ddl.GeneratedStored &&
+		(oldColumn.Generated == nil || *oldColumn.Generated != *column.Generated) {
+		result = append(result, Reason{Blocking, fmt.Sprintf("changes STORED generated column %s, which copies the table", column.Name)})
+	}
+
+	if moved {
+		result = append(result, Reason{Blocking, fmt.Sprintf("moves column %s, which rebuilds the table", column.Name)})
+	}
+
+	return result
+}
+
+func foreignKeyName(foreignKey ddl.ForeignKeyConstraint) string {
+	if foreignKey.Name != nil {
+		return *foreignKey.Name
+	}
+
+	return "(" + strings.Join(foreignKey.Columns, ",") + ")"
+}
+
+// findTable returns the table with the given schema and name, or nil if there is none.
+func findTable(tables []ddl.Table, schema, name string) *ddl.Table {
+	for i, table := range tables {
+		if table.Schema == schema && table.Name == name {
+			return &tables[i]
+		}
+	}
+
+	return nil
+}
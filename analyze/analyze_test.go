@@ -0,0 +1,112 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze_test
+
+import (
+	"github.com/golangee/sql/analyze"
+	"github.com/golangee/sql/ddl"
+	"github.com/golangee/sql/dialect/mysql"
+	"github.com/golangee/sql/diff"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestStatements(t *testing.T) {
+	sql, err := ioutil.ReadFile("testdata/migration.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := mysql.Parse(string(sql))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := analyze.Statements(nil, result.Statements)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual []string
+	for _, finding := range findings {
+		actual = append(actual, finding.String())
+	}
+
+	expected := []string{
+		"line 1: CREATE TABLE Customer: safe",
+		"line 9: CREATE TABLE Cart: safe",
+		"line 13: ALTER TABLE Customer: safe",
+		"line 14: ALTER TABLE Customer: safe",
+		"line 15: ALTER TABLE Customer: data-losing: narrows column Name from VARCHAR(200) to VARCHAR(50), which may truncate its values",
+		"line 16: ALTER TABLE Customer: blocking: changes the type of column Age from SMALLINT to INT, which copies the table",
+		"line 17: ALTER TABLE Customer: data-losing: converts column Age from INT to VARCHAR(10), which may change its values",
+		"line 18: ALTER TABLE Customer: safe",
+		"line 19: ALTER TABLE Customer: data-losing: removes the value 'active' from column Status, so rows with it lose their value",
+		"line 20: ALTER TABLE Customer: data-losing: makes column Email NOT NULL without a default, so existing NULL values are replaced or rejected",
+		"line 21: ALTER TABLE Customer: data-losing: adds NOT NULL column Created without a default, so existing rows get an implicit value like 0 or ''",
+		"line 22: ALTER TABLE Customer: safe",
+		"line 23: ALTER TABLE Customer: blocking: adds a CHECK constraint, which copies the table to validate its rows",
+		"line 24: ALTER TABLE Customer: safe",
+		"line 25: ALTER TABLE Customer: data-losing: drops column Age and its values",
+		"line 26: RENAME TABLE Cart: safe",
+		"line 27: TRUNCATE TABLE Basket: data-losing: deletes all rows of table Basket",
+		"line 28: DROP TABLE Basket: data-losing: drops table Basket and all of its rows",
+		"line 29: ALTER TABLE Customer: blocking: changes the type of column Name from VARCHAR(50) to VARCHAR(50) CHARACTER SET utf8mb4, which copies the table",
+		"line 30: ALTER TABLE Customer: data-losing: converts column Name from character set utf8mb4 to latin1, which cannot store all of its characters",
+		"line 31: ALTER TABLE Customer: blocking: changes the type of column Name from VARCHAR(50) CHARACTER SET latin1 to VARCHAR(50) COLLATE utf8mb4_bin, which copies the table",
+	}
+
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Unexpected findings:\n%s\nexpected:\n%s", strings.Join(actual, "\n"), strings.Join(expected, "\n"))
+	}
+
+	if severity := analyze.MaxSeverity(findings); severity != analyze.DataLoss {
+		t.Fatalf("Expected %v, but got %v", analyze.DataLoss, severity)
+	}
+}
+
+func TestAlterStatements(t *testing.T) {
+	oldTable := ddl.Table{Name: "Order", Columns: []ddl.Column{
+		{Name: "Id", Type: ddl.DataType{Name: "INT"}, NotNull: true, PrimaryKey: true},
+		{Name: "Total", Type: ddl.DataType{Name: "DECIMAL", Precision: intPtr(10), Scale: intPtr(2)}},
+	}}
+
+	newTable := oldTable.Clone()
+	newTable.Columns[1].Type.Unsigned = true
+
+	statements, err := diff.Table(oldTable, newTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := analyze.AlterStatements(oldTable, statements)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(findings) != 1 || findings[0].Severity() != analyze.DataLoss {
+		t.Fatalf("Expected a data-losing statement, but got %v", findings)
+	}
+
+	// Unknown tables cannot be analyzed.
+	if _, err := analyze.AlterStatements(ddl.Table{Name: "Cart"}, statements); err == nil {
+		t.Fatal("Expected an error for an unknown table")
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
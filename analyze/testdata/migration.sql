@@ -0,0 +1,31 @@
+CREATE TABLE Customer (
+  Id INT NOT NULL PRIMARY KEY,
+  Name VARCHAR(100),
+  Mail VARCHAR(100),
+  Age SMALLINT,
+  Status ENUM('new', 'active')
+);
+
+CREATE TABLE Cart (
+  Id INT NOT NULL PRIMARY KEY
+);
+
+ALTER TABLE Customer RENAME COLUMN Mail TO Email;
+ALTER TABLE Customer MODIFY Name VARCHAR(200);
+ALTER TABLE Customer MODIFY Name VARCHAR(50);
+ALTER TABLE Customer MODIFY Age INT;
+ALTER TABLE Customer MODIFY Age VARCHAR(10);
+ALTER TABLE Customer MODIFY Status ENUM('new', 'active', 'blocked');
+ALTER TABLE Customer MODIFY Status ENUM('new', 'blocked');
+ALTER TABLE Customer MODIFY Email VARCHAR(100) NOT NULL;
+ALTER TABLE Customer ADD COLUMN Created DATETIME NOT NULL;
+ALTER TABLE Customer ADD COLUMN Updated DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE Customer ADD CONSTRAINT AdultAge CHECK (Age >= 18);
+CREATE INDEX Email ON Customer (Email);
+ALTER TABLE Customer DROP COLUMN Age;
+RENAME TABLE Cart TO Basket;
+TRUNCATE TABLE Basket;
+DROP TABLE Basket;
+ALTER TABLE Customer MODIFY Name VARCHAR(50) CHARACTER SET utf8mb4;
+ALTER TABLE Customer MODIFY Name VARCHAR(50) CHARACTER SET latin1;
+ALTER TABLE Customer MODIFY Name VARCHAR(50) COLLATE utf8mb4_bin;
@@ -0,0 +1,239 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"fmt"
+	"github.com/golangee/sql/ddl"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// capacity describes the values a type can hold. Types of the same family convert into each other without
+// changing the values, as long as the range and scale of the new type contain those of the old one.
+type capacity struct {
+	family string
+	// min and max are the range of numbers, the number of characters or bytes, or the fractional seconds.
+	min, max float64
+	// scale is the number of digits after the decimal point.
+	scale int
+}
+
+// typeChange returns the reason for changing the type of a column, or false if it is unchanged or safe.
+func typeChange(column string, oldType, newType ddl.DataType) (Reason, bool) {
+	if reflect.DeepEqual(oldType.Clone(), newType.Clone()) {
+		return Reason{}, false
+	}
+
+	if reason, ok := charsetChange(column, oldType, newType); ok {
+		return reason, true
+	}
+
+	if oldType.IsCollection() && oldType.Name == newType.Name {
+		return collectionChange(column, oldType, newType)
+	}
+
+	oldCapacity, newCapacity := typeCapacity(oldType), typeCapacity(newType)
+
+	switch {
+	case oldCapacity.family != newCapacity.family:
+		return Reason{DataLoss, fmt.Sprintf("converts column %s from %s to %s, which may change its values",
+			column, oldType, newType)}, true
+	case newCapacity.min > oldCapacity.min || newCapacity.max < oldCapacity.max || newCapacity.scale < oldCapacity.scale:
+		return Reason{DataLoss, fmt.Sprintf("narrows column %s from %s to %s, which may truncate its values",
+			column, oldType, newType)}, true
+	case isVarcharExtension(oldType, newType):
+		return Reason{}, false
+	default:
+		return Reason{Blocking, fmt.Sprintf("changes the type of column %s from %s to %s, which copies the table",
+			column, oldType, newType)}, true
+	}
+}
+
+// charsetChange returns a reason, if the values of a column are converted into a character set,
+// that cannot store all characters of the old one. A character set, that is not declared on the
+// column or implied by its collation, is the default of the table and cannot be compared.
+func charsetChange(column string, oldType, newType ddl.DataType) (Reason, bool) {
+	if !isText(oldType) || !isText(newType) {
+		return Reason{}, false
+	}
+
+	oldCharset, newCharset := charset(oldType), charset(newType)
+	if oldCharset == "" || newCharset == "" || containsCharset(newCharset, oldCharset) {
+		return Reason{}, false
+	}
+
+	return Reason{DataLoss, fmt.Sprintf("converts column %s from character set %s to %s, which cannot store all of its characters",
+		column, oldCharset, newCharset)}, true
+}
+
+func isText(t ddl.DataType) bool {
+	return t.IsCollection() || typeCapacity(t).family == "STRING"
+}
+
+// charset returns the character set of a type in lower case, or an empty string if it is the default of the table.
+func charset(t ddl.DataType) string {
+	switch {
+	case t.CharacterSet != nil:
+		return strings.ToLower(*t.CharacterSet)
+	case t.Collate != nil:
+		// A collation is named after its character set, like utf8mb4_bin.
+		return strings.ToLower(strings.SplitN(*t.Collate, "_", 2)[0])
+	default:
+		return ""
+	}
+}
+
+// containsCharset returns true, if the outer character set can store all characters of the inner one.
+func containsCharset(outer, inner string) bool {
+	switch {
+	case outer == inner:
+		return true
+	case outer == "utf8mb4":
+		// utf8mb4 stores all of Unicode.
+		return inner != "binary"
+	case outer == "utf8" || outer == "utf8mb3":
+		// utf8mb3 stores the Basic Multilingual Plane.
+		return inner == "ascii" || inner == "latin1" || inner == "utf8" || inner == "utf8mb3"
+	default:
+		return inner == "ascii" && outer != "binary"
+	}
+}
+
+// collectionChange compares the values of two ENUM or SET types. Appending values only changes metadata.
+func collectionChange(column string, oldType, newType ddl.DataType) (Reason, bool) {
+	prefix := len(newType.Values) >= len(oldType.Values)
+
+	for i, value := range oldType.Values {
+		if !containsValue(newType.Values, value) {
+			return Reason{DataLoss, fmt.Sprintf("removes the value '%s' from column %s, so rows with it lose their value",
+				value, column)}, true
+		}
+
+		if prefix && newType.Values[i] != value {
+			prefix = false
+		}
+	}
+
+	sameValues := oldType.Clone()
+	sameValues.Values = newType.Clone().Values
+
+	if prefix && reflect.DeepEqual(sameValues, newType.Clone()) {
+		return Reason{}, false
+	}
+
+	return Reason{Blocking, fmt.Sprintf("changes the type of column %s from %s to %s, which copies the table",
+		column, oldType, newType)}, true
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isVarcharExtension returns true, if only the length of a VARCHAR grows, which MySQL does in place.
+func isVarcharExtension(oldType, newType ddl.DataType) bool {
+	if oldType.Name != "VARCHAR" || newType.Name != "VARCHAR" || oldType.Length == nil || newType.Length == nil {
+		return false
+	}
+
+	extended := oldType.Clone()
+	extended.Length = newType.Length
+
+	return reflect.DeepEqual(extended, newType.Clone())
+}
+
+func typeCapacity(t ddl.DataType) capacity {
+	switch t.Name {
+	case "BOOL", "BOOLEAN", "TINYINT":
+		return integerCapacity(8, t.Unsigned)
+	case "SMALLINT":
+		return integerCapacity(16, t.Unsigned)
+	case "MEDIUMINT":
+		return integerCapacity(24, t.Unsigned)
+	case "INT", "INTEGER":
+		return integerCapacity(32, t.Unsigned)
+	case "BIGINT":
+		return integerCapacity(64, t.Unsigned)
+	case "DECIMAL", "DEC", "NUMERIC", "FIXED":
+		precision, scale := 10, 0
+		if t.Precision != nil {
+			precision = *t.Precision
+		}
+
+		if t.Scale != nil {
+			scale = *t.Scale
+		}
+
+		return signedCapacity("DECIMAL", math.Pow10(precision-scale), t.Unsigned, scale)
+	case "FLOAT":
+		return signedCapacity("FLOAT", 4, t.Unsigned, 0)
+	case "DOUBLE", "DOUBLE PRECISION", "REAL":
+		return signedCapacity("FLOAT", 8, t.Unsigned, 0)
+	case "CHAR", "VARCHAR":
+		return capacity{family: "STRING", max: length(t, 1)}
+	case "TINYTEXT":
+		return capacity{family: "STRING", max: 1<<8 - 1}
+	case "TEXT":
+		return capacity{family: "STRING", max: length(t, 1<<16-1)}
+	case "MEDIUMTEXT":
+		return capacity{family: "STRING", max: 1<<24 - 1}
+	case "LONGTEXT":
+		return capacity{family: "STRING", max: 1<<32 - 1}
+	case "BINARY", "VARBINARY":
+		return capacity{family: "BINARY", max: length(t, 1)}
+	case "TINYBLOB":
+		return capacity{family: "BINARY", max: 1<<8 - 1}
+	case "BLOB":
+		return capacity{family: "BINARY", max: length(t, 1<<16-1)}
+	case "MEDIUMBLOB":
+		return capacity{family: "BINARY", max: 1<<24 - 1}
+	case "LONGBLOB":
+		return capacity{family: "BINARY", max: 1<<32 - 1}
+	default:
+		// The length of temporal types like DATETIME(6) is the precision of the fractional seconds.
+		return capacity{family: t.Name, max: length(t, 0)}
+	}
+}
+
+func integerCapacity(bits int, unsigned bool) capacity {
+	if unsigned {
+		return capacity{family: "INTEGER", max: math.Ldexp(1, bits) - 1}
+	}
+
+	return capacity{family: "INTEGER", min: -math.Ldexp(1, bits-1), max: math.Ldexp(1, bits-1) - 1}
+}
+
+func signedCapacity(family string, max float64, unsigned bool, scale int) capacity {
+	if unsigned {
+		return capacity{family: family, max: max, scale: scale}
+	}
+
+	return capacity{family: family, min: -max, max: max, scale: scale}
+}
+
+func length(t ddl.DataType, defaultLength int) float64 {
+	if t.Length != nil {
+		return float64(*t.Length)
+	}
+
+	return float64(defaultLength)
+}
@@ -15,11 +15,15 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"github.com/golangee/sql/analyze"
 	"github.com/golangee/sql/ddl"
 	"github.com/golangee/sql/diagram"
 	"github.com/golangee/sql/dialect/mysql"
+	"github.com/golangee/sql/diff"
+	"github.com/golangee/sql/migrate"
 	"github.com/golangee/sql/normalize"
 	"io/ioutil"
 	"os"
@@ -29,12 +33,21 @@ const (
 	OpDot       = "dot"
 	OpSvg       = "svg"
 	OpNormalize = "norm"
+	OpDiff      = "diff"
+	OpAnalyze   = "analyze"
 )
 
+// errDataLoss is returned by the 'analyze' operation, so that a build can fail on data-losing statements.
+var errDataLoss = errors.New("found data-losing statements")
+
 func main() {
 	sqlFile := flag.String("sql-file", "", "the sql file to parse")
 	dialect := flag.String("dialect", "mysql", "the sql dialect parser, one of (mysql)")
-	operation := flag.String("op", "", "the operation to perform, one of (svg|dot|norm). 'svg' to print an svg to stdout, 'dot' to print the dot representation of the graph, 'norm' to normalize the SQL.")
+	operation := flag.String("op", "", "the operation to perform, one of (svg|dot|norm|diff|analyze). 'svg' to print an svg to stdout, 'dot' to print the dot representation of the graph, 'norm' to normalize the SQL, 'diff' to print the statements that migrate the tables of sql-file into those of target-file, 'analyze' to classify the statements of sql-file, which are applied to the base schema, as safe, blocking or data-losing and exit with 1 on data-losing ones.")
+	targetFile := flag.String("target-file", "", "the sql file with the target tables for the 'diff' operation")
+	base := flag.String("base", "", "the schema, to which the 'analyze' operation applies the statements: an sql file, or a directory of versioned migrations like 0001_init.sql. Empty for no tables.")
+	renameThreshold := flag.Float64("rename-threshold", 0.8, "the confidence between 0 and 1, from which the 'diff' operation renames tables and columns instead of dropping and adding them. Less confident renames are reported on stderr.")
+	stripVolatile := flag.Bool("strip-volatile", false, "remove table options that depend on the data, like AUTO_INCREMENT=1234, when normalizing.")
 
 	flag.Parse()
 
@@ -45,40 +58,40 @@ func main() {
 		return
 	}
 
-	if err := run(*sqlFile, *dialect, *operation); err != nil {
-		panic(err)
+	if *operation == OpDiff && *targetFile == "" {
+		fmt.Println("invalid usage: diff requires a target-file")
+		flag.PrintDefaults()
+		os.Exit(-1)
+		return
 	}
-}
 
-// run actually evaluate and runs the converter command.
-func run(sqlFile, dialect, op string) error {
+	if err := run(*sqlFile, *targetFile, *base, *dialect, *operation, *stripVolatile, *renameThreshold); err != nil {
+		if errors.Is(err, errDataLoss) {
+			fmt.Println(err)
+			os.Exit(1)
+		}
 
-	// Open and parse file
-	fileContents, err := ioutil.ReadFile(os.Args[1])
-	if err != nil {
-		return fmt.Errorf("cannot load sql-file '%s': %w", sqlFile, err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
+}
 
-	var parseResult *ddl.ParseResult
-	switch dialect {
-	case "mysql":
-		parseResult, err = mysql.Parse(string(fileContents))
-		if err != nil {
-			return fmt.Errorf("unable to parse mysql: %w", err)
-		}
-	default:
-		return fmt.Errorf("unsupported dialect: %s", dialect)
+// run actually evaluate and runs the converter command.
+func run(sqlFile, targetFile, base, dialect, op string, stripVolatile bool, renameThreshold float64) error {
+	parseResult, err := parseFile(sqlFile, dialect)
+	if err != nil {
+		return err
 	}
 
 	// Check for a valid operation.
 	switch op {
 	// Perform desired operation.
 	case OpDot:
-		dot := diagram.GenerateDot(parseResult.Tables, diagram.TwoPi)
+		dot := diagram.GenerateDotWithViews(parseResult.Tables, parseResult.Views, diagram.TwoPi)
 		fmt.Println(dot)
 
 	case OpSvg:
-		dot := diagram.GenerateDot(parseResult.Tables, diagram.TwoPi)
+		dot := diagram.GenerateDotWithViews(parseResult.Tables, parseResult.Views, diagram.TwoPi)
 
 		svg, err := diagram.DotToSvg(dot)
 		if err != nil {
@@ -88,14 +101,135 @@ func run(sqlFile, dialect, op string) error {
 		fmt.Println(svg)
 
 	case OpNormalize:
-		normed := normalize.Tables(parseResult.Tables)
+		tables := parseResult.Tables
+		if stripVolatile {
+			tables = normalize.StripVolatile(tables)
+		}
+
+		normed := normalize.Databases(parseResult.Databases)
+		fmt.Print(normed)
+		normed = normalize.Tables(tables)
+		fmt.Print(normed)
+		normed = normalize.Views(parseResult.Views)
+		fmt.Print(normed)
+		normed = normalize.Routines(parseResult.Routines)
+		fmt.Print(normed)
+		normed = normalize.Triggers(parseResult.Triggers)
 		fmt.Print(normed)
 		normed = normalize.AlterStatements(parseResult.AlterStatements)
 		fmt.Print(normed)
+		normed = normalize.SchemaStatements(parseResult.SchemaStatements)
+		fmt.Print(normed)
 		fmt.Println()
+
+	case OpDiff:
+		targetResult, err := parseFile(targetFile, dialect)
+		if err != nil {
+			return err
+		}
+
+		// Both files may contain migrations, so compare the tables that result from them.
+		from, err := ddl.NewSchemaFromFiles(ddl.ParsedFile{Name: sqlFile, Result: parseResult})
+		if err != nil {
+			return fmt.Errorf("unable to apply sql-file: %w", err)
+		}
+
+		to, err := ddl.NewSchemaFromFiles(ddl.ParsedFile{Name: targetFile, Result: targetResult})
+		if err != nil {
+			return fmt.Errorf("unable to apply target-file: %w", err)
+		}
+
+		renames := diff.Renames(from.Tables, to.Tables)
+		for _, rename := range renames {
+			if rename.Confidence < renameThreshold {
+				fmt.Fprintf(os.Stderr, "not renaming %s %s to %s (confidence %.2f)\n",
+					rename.Kind, rename.OldName, rename.NewName, rename.Confidence)
+			}
+		}
+
+		accepted := diff.Accepted(renames, renameThreshold)
+
+		statements, err := diff.TablesWithRenames(from.Tables, to.Tables, accepted)
+		if err != nil {
+			return fmt.Errorf("unable to diff: %w", err)
+		}
+
+		fmt.Println(normalize.Statements(statements))
+
+	case OpAnalyze:
+		tables, err := loadBase(base, dialect)
+		if err != nil {
+			return fmt.Errorf("unable to load the base schema: %w", err)
+		}
+
+		findings, err := analyze.Statements(tables, parseResult.Statements)
+		if err != nil {
+			return fmt.Errorf("unable to analyze: %w", err)
+		}
+
+		for _, finding := range findings {
+			fmt.Println(finding)
+		}
+
+		if analyze.MaxSeverity(findings) == analyze.DataLoss {
+			return errDataLoss
+		}
 	default:
 		return fmt.Errorf("invalid operation: %s", op)
 	}
 
 	return nil
 }
+
+// loadBase returns the tables of a schema file, or those that result from a directory of migrations.
+func loadBase(base, dialect string) ([]ddl.Table, error) {
+	if base == "" {
+		return nil, nil
+	}
+
+	info, err := os.Stat(base)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		schema, _, err := migrate.LoadSchema(base)
+		if err != nil {
+			return nil, err
+		}
+
+		return schema.Tables, nil
+	}
+
+	parseResult, err := parseFile(base, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := ddl.NewSchemaFromFiles(ddl.ParsedFile{Name: base, Result: parseResult})
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.Tables, nil
+}
+
+// parseFile loads and parses an sql file.
+func parseFile(sqlFile, dialect string) (*ddl.ParseResult, error) {
+	fileContents, err := ioutil.ReadFile(sqlFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load sql-file '%s': %w", sqlFile, err)
+	}
+
+	switch dialect {
+	case "mysql":
+		parseResult, err := mysql.Parse(string(fileContents))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse mysql: %w", err)
+		}
+
+		return parseResult, nil
+	default:
+		return nil, fmt.Errorf("unsupported dialect: %s", dialect)
+	}
+}
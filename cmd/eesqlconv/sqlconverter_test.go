@@ -0,0 +1,43 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRun_AnalyzeBaseDir(t *testing.T) {
+	// The migrations add the column Email, which is dropped again.
+	err := run("testdata/drop-email.sql", "", "../../migrate/testdata/shop", "mysql", OpAnalyze, false, 0.8)
+	if !errors.Is(err, errDataLoss) {
+		t.Fatalf("Expected %v, but got %v", errDataLoss, err)
+	}
+}
+
+func TestRun_AnalyzeBaseFile(t *testing.T) {
+	err := run("testdata/add-note.sql", "", "../../migrate/testdata/shop/0001_init.sql", "mysql", OpAnalyze, false, 0.8)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRun_AnalyzeWithoutBase(t *testing.T) {
+	// Without a base schema, the altered table is missing.
+	err := run("testdata/add-note.sql", "", "", "mysql", OpAnalyze, false, 0.8)
+	if err == nil || errors.Is(err, errDataLoss) {
+		t.Fatalf("Expected an error for the missing table, but got %v", err)
+	}
+}
@@ -0,0 +1 @@
+ALTER TABLE Customer ADD COLUMN Note TEXT;
@@ -0,0 +1 @@
+ALTER TABLE Customer DROP COLUMN Email;
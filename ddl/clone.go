@@ -0,0 +1,279 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+// The Clone methods return deep copies, which share no slices or pointers with the original.
+// Empty slices become nil, which has the same meaning in the model.
+
+// Clone returns a deep copy of the schema.
+func (s Schema) Clone() Schema {
+	return Schema{Tables: cloneTables(s.Tables)}
+}
+
+func cloneTables(tables []Table) []Table {
+	if len(tables) == 0 {
+		return nil
+	}
+
+	result := make([]Table, len(tables))
+	for i, table := range tables {
+		result[i] = table.Clone()
+	}
+
+	return result
+}
+
+// Clone returns a deep copy of the table.
+func (t Table) Clone() Table {
+	result := t
+	result.Columns = nil
+	result.UniqueConstraints = nil
+	result.ForeignKeys = nil
+	result.Keys = nil
+	result.Checks = nil
+
+	for _, column := range t.Columns {
+		result.Columns = append(result.Columns, column.Clone())
+	}
+
+	if t.PrimaryKey != nil {
+		primaryKey := t.PrimaryKey.Clone()
+		result.PrimaryKey = &primaryKey
+	}
+
+	for _, unique := range t.UniqueConstraints {
+		result.UniqueConstraints = append(result.UniqueConstraints, unique.Clone())
+	}
+
+	for _, foreignKey := range t.ForeignKeys {
+		result.ForeignKeys = append(result.ForeignKeys, foreignKey.Clone())
+	}
+
+	for _, key := range t.Keys {
+		result.Keys = append(result.Keys, key.Clone())
+	}
+
+	result.Checks = cloneChecks(t.Checks)
+	result.Options = t.Options.Clone()
+
+	if t.Partitioning != nil {
+		partitioning := t.Partitioning.Clone()
+		result.Partitioning = &partitioning
+	}
+
+	return result
+}
+
+// Clone returns a deep copy of the options.
+func (o TableOptions) Clone() TableOptions {
+	return TableOptions{
+		Engine:        cloneString(o.Engine),
+		AutoIncrement: cloneString(o.AutoIncrement),
+		CharacterSet:  cloneString(o.CharacterSet),
+		Collate:       cloneString(o.Collate),
+		Comment:       cloneString(o.Comment),
+		RowFormat:     cloneString(o.RowFormat),
+		Others:        cloneStrings(o.Others),
+	}
+}
+
+// Clone returns a deep copy of the column.
+func (c Column) Clone() Column {
+	result := c
+	result.Type = c.Type.Clone()
+	result.Default = cloneString(c.Default)
+	result.OnUpdate = cloneString(c.OnUpdate)
+	result.Comment = cloneString(c.Comment)
+	result.Checks = cloneChecks(c.Checks)
+
+	if c.Generated != nil {
+		generated := *c.Generated
+		result.Generated = &generated
+	}
+
+	return result
+}
+
+// Clone returns a deep copy of the data type.
+func (t DataType) Clone() DataType {
+	result := t
+	result.Length = cloneInt(t.Length)
+	result.Precision = cloneInt(t.Precision)
+	result.Scale = cloneInt(t.Scale)
+	result.Values = cloneStrings(t.Values)
+	result.CharacterSet = cloneString(t.CharacterSet)
+	result.Collate = cloneString(t.Collate)
+
+	return result
+}
+
+// Clone returns a deep copy of the constraint.
+func (k PrimaryKeyConstraint) Clone() PrimaryKeyConstraint {
+	return PrimaryKeyConstraint{
+		Name:    cloneString(k.Name),
+		Parts:   cloneParts(k.Parts),
+		Options: k.Options.Clone(),
+	}
+}
+
+// Clone returns a deep copy of the constraint.
+func (u UniqueConstraint) Clone() UniqueConstraint {
+	return UniqueConstraint{
+		Name:    cloneString(u.Name),
+		Parts:   cloneParts(u.Parts),
+		Options: u.Options.Clone(),
+	}
+}
+
+// Clone returns a deep copy of the constraint.
+func (c CheckConstraint) Clone() CheckConstraint {
+	result := c
+	result.Name = cloneString(c.Name)
+
+	return result
+}
+
+func cloneChecks(checks []CheckConstraint) []CheckConstraint {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	result := make([]CheckConstraint, len(checks))
+	for i, check := range checks {
+		result[i] = check.Clone()
+	}
+
+	return result
+}
+
+// Clone returns a deep copy of the constraint.
+func (f ForeignKeyConstraint) Clone() ForeignKeyConstraint {
+	result := f
+	result.Name = cloneString(f.Name)
+	result.Columns = cloneStrings(f.Columns)
+	result.ReferenceColumns = cloneStrings(f.ReferenceColumns)
+
+	return result
+}
+
+// Clone returns a deep copy of the index.
+func (k Key) Clone() Key {
+	return Key{
+		Name:    cloneString(k.Name),
+		Kind:    k.Kind,
+		Parts:   cloneParts(k.Parts),
+		Options: k.Options.Clone(),
+	}
+}
+
+// Clone returns a deep copy of the options.
+func (o IndexOptions) Clone() IndexOptions {
+	result := o
+	result.KeyBlockSize = cloneString(o.KeyBlockSize)
+	result.Parser = cloneString(o.Parser)
+	result.Comment = cloneString(o.Comment)
+
+	return result
+}
+
+func cloneParts(parts []IndexPart) []IndexPart {
+	if len(parts) == 0 {
+		return nil
+	}
+
+	return append([]IndexPart(nil), parts...)
+}
+
+// Clone returns a deep copy of the partitioning.
+func (p Partitioning) Clone() Partitioning {
+	result := Partitioning{
+		Function:          p.Function.clone(),
+		Count:             cloneInt(p.Count),
+		SubpartitionCount: cloneInt(p.SubpartitionCount),
+	}
+
+	if p.Subpartitioning != nil {
+		subpartitioning := p.Subpartitioning.clone()
+		result.Subpartitioning = &subpartitioning
+	}
+
+	for _, partition := range p.Partitions {
+		clone := partition
+		clone.LessThan = cloneStrings(partition.LessThan)
+		clone.In = cloneStrings(partition.In)
+		clone.Options = partition.Options.clone()
+		clone.Subpartitions = nil
+
+		for _, subpartition := range partition.Subpartitions {
+			clone.Subpartitions = append(clone.Subpartitions, Subpartition{
+				Name:    subpartition.Name,
+				Options: subpartition.Options.clone(),
+			})
+		}
+
+		result.Partitions = append(result.Partitions, clone)
+	}
+
+	return result
+}
+
+func (f PartitionFunction) clone() PartitionFunction {
+	result := f
+	result.Columns = cloneStrings(f.Columns)
+	result.Algorithm = cloneInt(f.Algorithm)
+
+	return result
+}
+
+func (o PartitionOptions) clone() PartitionOptions {
+	return PartitionOptions{
+		Engine:         cloneString(o.Engine),
+		Comment:        cloneString(o.Comment),
+		DataDirectory:  cloneString(o.DataDirectory),
+		IndexDirectory: cloneString(o.IndexDirectory),
+		MaxRows:        cloneInt(o.MaxRows),
+		MinRows:        cloneInt(o.MinRows),
+		Tablespace:     cloneString(o.Tablespace),
+		NodeGroup:      cloneString(o.NodeGroup),
+	}
+}
+
+func cloneString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+
+	clone := *s
+
+	return &clone
+}
+
+func cloneInt(i *int) *int {
+	if i == nil {
+		return nil
+	}
+
+	clone := *i
+
+	return &clone
+}
+
+func cloneStrings(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return append([]string(nil), s...)
+}
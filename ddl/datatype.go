@@ -0,0 +1,92 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DataType is the type of a Column, e.g. VARCHAR(255) or DECIMAL(10,2) UNSIGNED.
+type DataType struct {
+	// Name is the upper case name of the type without any attributes, e.g. VARCHAR or DOUBLE PRECISION.
+	Name string
+	// Length is the length or display width, like 255 in VARCHAR(255). Might be nil.
+	Length *int
+	// Precision is the total number of digits, like 10 in DECIMAL(10,2). Might be nil.
+	Precision *int
+	// Scale is the number of digits after the decimal point, like 2 in DECIMAL(10,2). Might be nil.
+	Scale *int
+	// Unsigned is set for numeric types that do not allow negative values.
+	Unsigned bool
+	// Zerofill is set for numeric types that are padded with zeros up to their display width.
+	Zerofill bool
+	// Binary is set, if the BINARY attribute is given, like in VARCHAR(10) BINARY.
+	Binary bool
+	// Values are the unquoted members of an ENUM or SET type.
+	Values []string
+	// CharacterSet is the name of the CHARACTER SET of a string type. Might be nil.
+	CharacterSet *string
+	// Collate is the name of the COLLATE collation of a string type. Might be nil.
+	Collate *string
+}
+
+// IsCollection returns true for ENUM and SET types, which only allow the values listed in Values.
+func (t DataType) IsCollection() bool {
+	return t.Name == "ENUM" || t.Name == "SET"
+}
+
+// String renders the type as SQL in a canonical form.
+func (t DataType) String() string {
+	result := t.Name
+
+	switch {
+	case len(t.Values) > 0:
+		values := make([]string, 0, len(t.Values))
+		for _, value := range t.Values {
+			values = append(values, "'"+strings.ReplaceAll(strings.ReplaceAll(value, `\`, `\\`), "'", "''")+"'")
+		}
+
+		result += "(" + strings.Join(values, ",") + ")"
+	case t.Length != nil:
+		result += fmt.Sprintf("(%d)", *t.Length)
+	case t.Precision != nil && t.Scale != nil:
+		result += fmt.Sprintf("(%d,%d)", *t.Precision, *t.Scale)
+	case t.Precision != nil:
+		result += fmt.Sprintf("(%d)", *t.Precision)
+	}
+
+	if t.Unsigned {
+		result += " UNSIGNED"
+	}
+
+	if t.Zerofill {
+		result += " ZEROFILL"
+	}
+
+	if t.Binary {
+		result += " BINARY"
+	}
+
+	if t.CharacterSet != nil {
+		result += " CHARACTER SET " + *t.CharacterSet
+	}
+
+	if t.Collate != nil {
+		result += " COLLATE " + *t.Collate
+	}
+
+	return result
+}
@@ -0,0 +1,172 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Equal returns true, if both tables define the same table. Unlike a plain comparison, it
+// ignores the order of indexes and constraints, whether a PRIMARY KEY or UNIQUE constraint
+// is declared on a column or on the table, whether an index is explicitly given the name that
+// MySQL would give it, the name of the PRIMARY KEY, an explicit NULL, the case of the names of columns,
+// indexes and constraints, and IF NOT EXISTS. The order of the columns is significant.
+func (t Table) Equal(other Table) bool {
+	return reflect.DeepEqual(canonicalTable(t), canonicalTable(other))
+}
+
+// Equal returns true, if both columns have the same definition. Like Table.Equal, it ignores
+// an explicit NULL and the case of the names.
+func (c Column) Equal(other Column) bool {
+	return reflect.DeepEqual(canonicalColumn(c), canonicalColumn(other))
+}
+
+// canonicalColumn returns a copy of the column, in which equivalent definitions are written the same way.
+func canonicalColumn(column Column) Column {
+	result := column.Clone()
+	result.Name = strings.ToLower(result.Name)
+
+	// A column is nullable, unless it is declared NOT NULL.
+	if !result.NotNull {
+		result.Null = false
+	}
+
+	foldCheckNames(result.Checks)
+
+	return result
+}
+
+// canonicalTable returns a copy of the table, in which equivalent definitions are written the same way.
+func canonicalTable(table Table) Table {
+	result := table.Clone()
+	result.IfNotExists = false
+
+	for i, column := range result.Columns {
+		if column.PrimaryKey {
+			result.PrimaryKey = &PrimaryKeyConstraint{Parts: []IndexPart{{Column: column.Name}}}
+			result.Columns[i].PrimaryKey = false
+		}
+
+		// The index of a column level UNIQUE constraint is named after the column.
+		if column.Unique {
+			name := column.Name
+			result.UniqueConstraints = append(result.UniqueConstraints, UniqueConstraint{
+				Name:  &name,
+				Parts: []IndexPart{{Column: column.Name}},
+			})
+			result.Columns[i].Unique = false
+		}
+	}
+
+	result.nameIndexes()
+
+	// MySQL always names the primary key PRIMARY.
+	if result.PrimaryKey != nil {
+		result.PrimaryKey.Name = nil
+		foldIndexParts(result.PrimaryKey.Parts)
+	}
+
+	for i, column := range result.Columns {
+		result.Columns[i] = canonicalColumn(column)
+	}
+
+	for i, unique := range result.UniqueConstraints {
+		*unique.Name = strings.ToLower(*unique.Name)
+		foldIndexParts(result.UniqueConstraints[i].Parts)
+	}
+
+	for i, key := range result.Keys {
+		*key.Name = strings.ToLower(*key.Name)
+		foldIndexParts(result.Keys[i].Parts)
+	}
+
+	for i, foreignKey := range result.ForeignKeys {
+		if foreignKey.Name != nil {
+			*foreignKey.Name = strings.ToLower(*foreignKey.Name)
+		}
+
+		foldNames(result.ForeignKeys[i].Columns)
+		foldNames(result.ForeignKeys[i].ReferenceColumns)
+	}
+
+	foldCheckNames(result.Checks)
+
+	// The order of the table options is insignificant.
+	sort.Strings(result.Options.Others)
+
+	sortCanonical(result.UniqueConstraints)
+	sortCanonical(result.ForeignKeys)
+	sortCanonical(result.Keys)
+	sortCanonical(result.Checks)
+
+	return result
+}
+
+// foldNames converts the names to lower case, because the names of columns are case-insensitive.
+func foldNames(names []string) {
+	for i, name := range names {
+		names[i] = strings.ToLower(name)
+	}
+}
+
+func foldIndexParts(parts []IndexPart) {
+	for i, part := range parts {
+		parts[i].Column = strings.ToLower(part.Column)
+	}
+}
+
+func foldCheckNames(checks []CheckConstraint) {
+	for _, check := range checks {
+		if check.Name != nil {
+			*check.Name = strings.ToLower(*check.Name)
+		}
+	}
+}
+
+// sortCanonical sorts a slice of constraints by their JSON representation, which includes
+// all of their properties, so that equal constraints are always sorted the same way.
+func sortCanonical(slice interface{}) {
+	value := reflect.ValueOf(slice)
+	keys := make([]string, value.Len())
+
+	for i := range keys {
+		// The model only consists of strings, numbers, booleans, pointers and slices, which never fail.
+		key, _ := json.Marshal(value.Index(i).Interface())
+		keys[i] = string(key)
+	}
+
+	sort.Sort(canonicalOrder{keys: keys, swap: reflect.Swapper(slice)})
+}
+
+type canonicalOrder struct {
+	keys []string
+	swap func(i, j int)
+}
+
+func (o canonicalOrder) Len() int {
+	return len(o.keys)
+}
+
+func (o canonicalOrder) Less(i, j int) bool {
+	return o.keys[i] < o.keys[j]
+}
+
+func (o canonicalOrder) Swap(i, j int) {
+	o.keys[i], o.keys[j] = o.keys[j], o.keys[i]
+	o.swap(i, j)
+}
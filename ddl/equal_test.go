@@ -0,0 +1,128 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"github.com/golangee/sql/ddl"
+	"testing"
+)
+
+func TestTable_Clone(t *testing.T) {
+	comment := "name of the user"
+	length := 50
+	table := ddl.Table{
+		Name: "User",
+		Columns: []ddl.Column{
+			{Name: "Id", PrimaryKey: true},
+			{Name: "Name", Type: ddl.DataType{Name: "VARCHAR", Length: &length}, Comment: &comment},
+		},
+		Keys: []ddl.Key{{Parts: []ddl.IndexPart{{Column: "Name"}}}},
+	}
+
+	clone := table.Clone()
+	if !clone.Equal(table) {
+		t.Fatalf("Expected the clone to be equal")
+	}
+
+	clone.Columns[1].Name = "FullName"
+	*clone.Columns[1].Comment = "changed"
+	*clone.Columns[1].Type.Length = 100
+	clone.Keys[0].Parts[0].Column = "FullName"
+
+	if table.Columns[1].Name != "Name" || comment != "name of the user" || length != 50 ||
+		table.Keys[0].Parts[0].Column != "Name" {
+		t.Fatalf("Changing the clone changed the original table")
+	}
+}
+
+func TestTable_Equal(t *testing.T) {
+	a, b := "a", "b"
+	table := ddl.Table{
+		Name:    "T",
+		Columns: []ddl.Column{{Name: "Id", PrimaryKey: true}, {Name: "Code", Unique: true}},
+		Keys: []ddl.Key{
+			{Name: &a, Parts: []ddl.IndexPart{{Column: "Id"}}},
+			{Name: &b, Parts: []ddl.IndexPart{{Column: "Code"}}},
+		},
+		Checks: []ddl.CheckConstraint{},
+	}
+
+	// The same table, but with table level constraints and indexes in another order.
+	equivalent := ddl.Table{
+		Name:              "T",
+		IfNotExists:       true,
+		Columns:           []ddl.Column{{Name: "Id"}, {Name: "Code"}},
+		PrimaryKey:        &ddl.PrimaryKeyConstraint{Parts: []ddl.IndexPart{{Column: "Id"}}},
+		UniqueConstraints: []ddl.UniqueConstraint{{Name: stringPtr("Code"), Parts: []ddl.IndexPart{{Column: "Code"}}}},
+		Keys: []ddl.Key{
+			{Name: stringPtr("b"), Parts: []ddl.IndexPart{{Column: "Code"}}},
+			{Name: stringPtr("a"), Parts: []ddl.IndexPart{{Column: "Id"}}},
+		},
+	}
+
+	if !table.Equal(equivalent) || !equivalent.Equal(table) {
+		t.Fatalf("Expected the tables to be equal")
+	}
+
+	reordered := equivalent.Clone()
+	reordered.Columns[0], reordered.Columns[1] = reordered.Columns[1], reordered.Columns[0]
+
+	if table.Equal(reordered) {
+		t.Fatalf("Expected the order of the columns to be significant")
+	}
+
+	changed := equivalent.Clone()
+	changed.Keys[0].Options.Type = ddl.IndexHash
+
+	if table.Equal(changed) {
+		t.Fatalf("Expected the options of an index to be significant")
+	}
+}
+
+func TestTable_EqualNames(t *testing.T) {
+	table := ddl.Table{
+		Name:       "T",
+		Columns:    []ddl.Column{{Name: "Id", NotNull: true}, {Name: "Code", Null: true}},
+		PrimaryKey: &ddl.PrimaryKeyConstraint{Name: stringPtr("pk"), Parts: []ddl.IndexPart{{Column: "Id"}}},
+		Keys:       []ddl.Key{{Name: stringPtr("Code"), Parts: []ddl.IndexPart{{Column: "Code"}}}},
+	}
+
+	// Without an explicit NULL, a name of the primary key and with names in another case.
+	equivalent := ddl.Table{
+		Name:       "T",
+		Columns:    []ddl.Column{{Name: "ID", NotNull: true}, {Name: "code"}},
+		PrimaryKey: &ddl.PrimaryKeyConstraint{Parts: []ddl.IndexPart{{Column: "id"}}},
+		Keys:       []ddl.Key{{Parts: []ddl.IndexPart{{Column: "CODE"}}}},
+	}
+
+	if !table.Equal(equivalent) || !equivalent.Equal(table) {
+		t.Fatalf("Expected the tables to be equal")
+	}
+
+	if !table.Columns[1].Equal(equivalent.Columns[1]) {
+		t.Fatalf("Expected the columns to be equal")
+	}
+
+	renamed := equivalent.Clone()
+	renamed.Name = "t"
+
+	if table.Equal(renamed) {
+		t.Fatalf("Expected the case of the table name to be significant")
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
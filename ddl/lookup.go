@@ -0,0 +1,224 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Names of columns, indexes and constraints are case-insensitive in MySQL, names of tables and databases
+// are case-sensitive by default, because they are stored as files.
+
+// PrimaryIndexName is the name of the index of a PRIMARY KEY.
+const PrimaryIndexName = "PRIMARY"
+
+// Index is any index of a table: the PRIMARY KEY, a UNIQUE constraint or a Key.
+type Index struct {
+	// Name is the name of the index. MySQL names unnamed indexes after their first column,
+	// and appends _2, _3 and so on, if that name is taken.
+	Name string
+	// Primary is set for the index of the PRIMARY KEY.
+	Primary bool
+	// Unique is set for the PRIMARY KEY and UNIQUE constraints.
+	Unique bool
+	// Kind is FULLTEXT or SPATIAL. Empty for other indexes.
+	Kind IndexKind
+	// Parts are the indexed columns.
+	Parts []IndexPart
+	// Options are the options of the index.
+	Options IndexOptions
+}
+
+// Column returns the column with the given name, ignoring the case, or nil if there is none.
+// The result points into Columns.
+func (t *Table) Column(name string) *Column {
+	if i := t.columnIndex(name); i >= 0 {
+		return &t.Columns[i]
+	}
+
+	return nil
+}
+
+// columnIndex returns the position of the column with the given name, or -1 if there is none.
+func (t *Table) columnIndex(name string) int {
+	for i, column := range t.Columns {
+		if strings.EqualFold(column.Name, name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Index returns the index with the given name, ignoring the case.
+// The index of the PRIMARY KEY is named PRIMARY.
+func (t *Table) Index(name string) (Index, bool) {
+	for _, index := range t.Indexes() {
+		if strings.EqualFold(index.Name, name) {
+			return index, true
+		}
+	}
+
+	return Index{}, false
+}
+
+// Indexes returns all indexes of the table, including those of column level constraints.
+// The PRIMARY KEY comes first, then the UNIQUE constraints and the Keys in their declaration order.
+func (t *Table) Indexes() []Index {
+	var result []Index
+
+	if t.PrimaryKey != nil {
+		result = append(result, Index{
+			Name:    PrimaryIndexName,
+			Primary: true,
+			Unique:  true,
+			Parts:   t.PrimaryKey.Parts,
+			Options: t.PrimaryKey.Options,
+		})
+	}
+
+	for _, column := range t.Columns {
+		if column.PrimaryKey {
+			result = append(result, Index{
+				Name:    PrimaryIndexName,
+				Primary: true,
+				Unique:  true,
+				Parts:   []IndexPart{{Column: column.Name}},
+			})
+		}
+	}
+
+	for _, column := range t.Columns {
+		if column.Unique {
+			result = append(result, Index{
+				Name:   column.Name,
+				Unique: true,
+				Parts:  []IndexPart{{Column: column.Name}},
+			})
+		}
+	}
+
+	uniqueNames, keyNames := t.indexNames()
+
+	for i, unique := range t.UniqueConstraints {
+		result = append(result, Index{
+			Name:    uniqueNames[i],
+			Unique:  true,
+			Parts:   unique.Parts,
+			Options: unique.Options,
+		})
+	}
+
+	for i, key := range t.Keys {
+		result = append(result, Index{
+			Name:    keyNames[i],
+			Kind:    key.Kind,
+			Parts:   key.Parts,
+			Options: key.Options,
+		})
+	}
+
+	return result
+}
+
+// indexNames returns the names of the UNIQUE constraints and the Keys. MySQL names an unnamed index
+// after its first column, and appends _2, _3 and so on, if that name is already taken.
+func (t *Table) indexNames() (uniqueNames, keyNames []string) {
+	taken := map[string]bool{strings.ToLower(PrimaryIndexName): true}
+
+	for _, column := range t.Columns {
+		if column.Unique {
+			taken[strings.ToLower(column.Name)] = true
+		}
+	}
+
+	for _, unique := range t.UniqueConstraints {
+		if unique.Name != nil {
+			taken[strings.ToLower(*unique.Name)] = true
+		}
+	}
+
+	for _, key := range t.Keys {
+		if key.Name != nil {
+			taken[strings.ToLower(*key.Name)] = true
+		}
+	}
+
+	name := func(explicit *string, parts []IndexPart) string {
+		if explicit != nil {
+			return *explicit
+		}
+
+		if len(parts) == 0 {
+			return ""
+		}
+
+		result := parts[0].Column
+		for i := 2; taken[strings.ToLower(result)]; i++ {
+			result = fmt.Sprintf("%s_%d", parts[0].Column, i)
+		}
+
+		taken[strings.ToLower(result)] = true
+
+		return result
+	}
+
+	for _, unique := range t.UniqueConstraints {
+		uniqueNames = append(uniqueNames, name(unique.Name, unique.Parts))
+	}
+
+	for _, key := range t.Keys {
+		keyNames = append(keyNames, name(key.Name, key.Parts))
+	}
+
+	return uniqueNames, keyNames
+}
+
+// nameIndexes names all unnamed UNIQUE constraints and Keys like MySQL does, so that they keep
+// their names, when other indexes are added or dropped.
+func (t *Table) nameIndexes() {
+	uniqueNames, keyNames := t.indexNames()
+
+	for i := range t.UniqueConstraints {
+		if t.UniqueConstraints[i].Name == nil {
+			t.UniqueConstraints[i].Name = &uniqueNames[i]
+		}
+	}
+
+	for i := range t.Keys {
+		if t.Keys[i].Name == nil {
+			t.Keys[i].Name = &keyNames[i]
+		}
+	}
+}
+
+// Table returns the table with the given name, or nil if there is none. The name may be
+// qualified like shop.orders, otherwise the first table with that name in any database is returned.
+// The result points into Tables.
+func (s *Schema) Table(name string) *Table {
+	schema := ""
+	if dot := strings.IndexByte(name, '.'); dot >= 0 {
+		schema, name = name[:dot], name[dot+1:]
+	}
+
+	for i, table := range s.Tables {
+		if table.Name == name && (schema == "" || table.Schema == schema) {
+			return &s.Tables[i]
+		}
+	}
+
+	return nil
+}
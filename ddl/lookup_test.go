@@ -0,0 +1,81 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"github.com/golangee/sql/ddl"
+	"testing"
+)
+
+func TestTable_Column(t *testing.T) {
+	table := ddl.Table{Columns: []ddl.Column{{Name: "Id"}, {Name: "FirstName"}}}
+
+	column := table.Column("firstname")
+	if column == nil || column.Name != "FirstName" {
+		t.Fatalf("Expected to find the column regardless of its case, got %v", column)
+	}
+
+	if table.Column("LastName") != nil {
+		t.Fatalf("Expected no column")
+	}
+}
+
+func TestTable_Index(t *testing.T) {
+	table := ddl.Table{
+		Columns:           []ddl.Column{{Name: "Id", PrimaryKey: true}, {Name: "Mail", Unique: true}, {Name: "Name"}},
+		UniqueConstraints: []ddl.UniqueConstraint{{Parts: []ddl.IndexPart{{Column: "Name"}, {Column: "Mail"}}}},
+		Keys:              []ddl.Key{{Name: stringPtr("NameIndex"), Kind: ddl.IndexFulltext, Parts: []ddl.IndexPart{{Column: "Name"}}}},
+	}
+
+	var names []string
+	for _, index := range table.Indexes() {
+		names = append(names, index.Name)
+	}
+
+	if len(names) != 4 || names[0] != ddl.PrimaryIndexName || names[1] != "Mail" || names[2] != "Name" ||
+		names[3] != "NameIndex" {
+		t.Fatalf("Unexpected indexes %v", names)
+	}
+
+	index, ok := table.Index("nameindex")
+	if !ok || index.Kind != ddl.IndexFulltext || index.Unique {
+		t.Fatalf("Expected to find the index regardless of its case, got %+v", index)
+	}
+
+	if index, ok := table.Index("primary"); !ok || !index.Primary || index.Parts[0].Column != "Id" {
+		t.Fatalf("Expected to find the primary key, got %+v", index)
+	}
+
+	if _, ok := table.Index("Missing"); ok {
+		t.Fatalf("Expected no index")
+	}
+}
+
+func TestSchema_Table(t *testing.T) {
+	schema := ddl.Schema{Tables: []ddl.Table{{Schema: "crm", Name: "User"}, {Schema: "shop", Name: "User"}}}
+
+	if table := schema.Table("shop.User"); table == nil || table.Schema != "shop" {
+		t.Fatalf("Expected to find the qualified table, got %v", table)
+	}
+
+	if table := schema.Table("User"); table == nil || table.Schema != "crm" {
+		t.Fatalf("Expected to find the first table with the name, got %v", table)
+	}
+
+	// Table names are case-sensitive.
+	if schema.Table("user") != nil || schema.Table("other.User") != nil {
+		t.Fatalf("Expected no table")
+	}
+}
@@ -16,53 +16,411 @@ package ddl
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ParseResult contains all information that could be parsed from the SQL.
 type ParseResult struct {
+	// Databases are all parsed CREATE DATABASE statements.
+	Databases []Database
 	// Tables are all parsed CREATE TABLE statements.
 	Tables []Table
 	// AlterStatements are all parsed ALTER TABLE statements.
 	AlterStatements []AlterStatement
+	// SchemaStatements are all parsed RENAME TABLE, DROP TABLE and TRUNCATE TABLE statements.
+	SchemaStatements []SchemaStatement
+	// Statements are the CREATE TABLE, ALTER TABLE and schema statements from above in the
+	// order of the SQL, which is needed to replay them.
+	Statements []Statement
+	// Views are all parsed CREATE VIEW statements.
+	Views []View
+	// Triggers are all parsed CREATE TRIGGER statements, in the order of their declaration.
+	Triggers []Trigger
+	// Routines are all parsed CREATE PROCEDURE and CREATE FUNCTION statements.
+	Routines []Routine
+}
+
+// Database represents a CREATE DATABASE or CREATE SCHEMA statement.
+type Database struct {
+	Name        string
+	IfNotExists bool
+	// CharacterSet is the default character set of the tables. Might be nil.
+	CharacterSet *string
+	// Collate is the default collation of the tables. Might be nil.
+	Collate *string
 }
 
 // Table represents a CREATE definition for a single SQL table.
 type Table struct {
+	// Schema is the database that contains the table, either given explicitly like in shop.orders
+	// or by a preceding USE statement. Empty, if it is unknown.
+	Schema      string
 	Name        string
 	IfNotExists bool
 	Columns     []Column
-	ForeignKeys []ForeignKeyConstraint
-	Keys        []Key
+	// PrimaryKey is the table level PRIMARY KEY constraint. Might be nil, e.g. if
+	// the primary key is declared on a column instead, see Column.PrimaryKey.
+	PrimaryKey *PrimaryKeyConstraint
+	// UniqueConstraints are the table level UNIQUE constraints. Column level
+	// constraints are declared by Column.Unique instead.
+	UniqueConstraints []UniqueConstraint
+	ForeignKeys       []ForeignKeyConstraint
+	Keys              []Key
+	// Checks are the table level CHECK constraints.
+	Checks []CheckConstraint
+	// Options are the table options that follow the definitions, like ENGINE=InnoDB.
+	Options TableOptions
+	// Partitioning is the PARTITION BY clause. Might be nil, if the table is not partitioned.
+	Partitioning *Partitioning
+}
+
+// TableOptions are the options of a CREATE TABLE statement.
+// Each of them might be nil, if it has not been declared.
+type TableOptions struct {
+	// Engine is the storage engine, e.g. InnoDB.
+	Engine *string
+	// AutoIncrement is the next value of the AUTO_INCREMENT column.
+	// mysqldump includes it, although it depends on the data in the table.
+	AutoIncrement *string
+	// CharacterSet is the default character set of the columns.
+	CharacterSet *string
+	// Collate is the default collation of the columns.
+	Collate *string
+	// Comment is the unquoted COMMENT of the table.
+	Comment *string
+	// RowFormat is the physical format of the rows, e.g. DYNAMIC.
+	RowFormat *string
+	// Others are all remaining options as written in the SQL, e.g. STATS_PERSISTENT=0.
+	Others []string
 }
 
 // A Column defined in a Table.
 type Column struct {
-	Name       string
-	Type       string
-	NotNull    bool
+	Name    string
+	Type    DataType
+	NotNull bool
+	// Null is set, if the column is explicitly declared as NULL.
+	Null       bool
 	PrimaryKey bool
 	Unique     bool
 	Default    *string
+	// OnUpdate is the value that is assigned on each update of a row, e.g. CURRENT_TIMESTAMP. Might be nil.
+	OnUpdate      *string
+	AutoIncrement bool
+	// Comment is the unquoted COMMENT of the column. Might be nil.
+	Comment *string
+	// Invisible is set, if the column is hidden from SELECT * queries.
+	Invisible bool
+	// Generated is set, if the value of the column is computed from an expression. Might be nil.
+	Generated *GeneratedColumn
+	// Checks are the CHECK constraints declared on this column.
+	Checks []CheckConstraint
+}
+
+// GeneratedColumn describes how the value of a generated column is computed, like in AS (a + b) STORED.
+type GeneratedColumn struct {
+	// Expression computes the value, as written in the SQL, e.g. a + b.
+	Expression string
+	// Storage is VIRTUAL, if the value is computed when a row is read, or STORED if it is written to disk.
+	Storage GeneratedStorage
+}
+
+// GeneratedStorage is the storage kind of a generated column.
+type GeneratedStorage string
+
+const (
+	GeneratedVirtual GeneratedStorage = "VIRTUAL"
+	GeneratedStored  GeneratedStorage = "STORED"
+)
+
+// PrimaryKeyConstraint is a table level PRIMARY KEY constraint, which may span multiple columns.
+type PrimaryKeyConstraint struct {
+	// Name is the name of the constraint. Might be nil if it has no name.
+	Name *string
+	// Parts are the columns of the primary key. The order is significant.
+	Parts []IndexPart
+	// Options are the options of the primary key index.
+	Options IndexOptions
+}
+
+// UniqueConstraint is a table level UNIQUE constraint, which may span multiple columns.
+type UniqueConstraint struct {
+	// Name is the name of the unique index. If only the constraint is named, like in
+	// CONSTRAINT 'name' UNIQUE ('column'), that name is used. Might be nil if it has no name.
+	Name *string
+	// Parts are the columns that must be unique in combination. The order is significant.
+	Parts []IndexPart
+	// Options are the options of the unique index.
+	Options IndexOptions
+}
+
+// CheckConstraint is a CHECK constraint, which validates each row by an expression.
+type CheckConstraint struct {
+	// Name is the name of the constraint. Might be nil if it has no name.
+	Name *string
+	// Expression is the condition that must hold, as written in the SQL, e.g. price > 0.
+	Expression string
+	// NotEnforced is set, if the constraint is declared as NOT ENFORCED.
+	NotEnforced bool
 }
 
 // ForeignKeyConstraint is a FOREIGN KEY constraint in SQL.
 type ForeignKeyConstraint struct {
-	Name            *string
-	Column          string
+	Name *string
+	// Columns are the columns of the constraining table, in declaration order.
+	// A composite foreign key has more than one column.
+	Columns []string
+	// ReferenceSchema is the database of ReferenceTable. It is the database of the constraining
+	// table, unless the reference is qualified like in REFERENCES crm.customers.
+	ReferenceSchema string
 	ReferenceTable  string
-	ReferenceColumn string
+	// ReferenceColumns are the referenced columns of ReferenceTable.
+	// They correspond to Columns by position.
+	ReferenceColumns []string
+	// Match is the MATCH clause of the reference. Empty, if none was given.
+	Match MatchType
+	// OnDelete is the action performed when a referenced row is deleted. Empty, if none was given.
+	OnDelete ReferenceAction
+	// OnUpdate is the action performed when a referenced row is updated. Empty, if none was given.
+	OnUpdate ReferenceAction
 }
 
+// ReferenceAction is a referential action of a FOREIGN KEY, like in ON DELETE CASCADE.
+type ReferenceAction string
+
+const (
+	ReferenceRestrict ReferenceAction = "RESTRICT"
+	ReferenceCascade  ReferenceAction = "CASCADE"
+	ReferenceSetNull  ReferenceAction = "SET NULL"
+	ReferenceNoAction ReferenceAction = "NO ACTION"
+)
+
+// MatchType is the MATCH clause of a FOREIGN KEY, like in MATCH FULL.
+type MatchType string
+
+const (
+	MatchFull    MatchType = "FULL"
+	MatchPartial MatchType = "PARTIAL"
+	MatchSimple  MatchType = "SIMPLE"
+)
+
 // Key is an SQL INDEX.
 type Key struct {
 	// Name is the name of this index. Might be nil if it has no name.
 	Name *string
-	// OnColumn is the name of the column, this index applies to.
-	OnColumn string
+	// Kind is FULLTEXT or SPATIAL. Empty for a plain index.
+	Kind IndexKind
+	// Parts are the indexed columns. The order is significant.
+	Parts []IndexPart
+	// Options are the options of the index, like USING BTREE.
+	Options IndexOptions
 }
 
+// IndexKind distinguishes special indexes from plain ones.
+type IndexKind string
+
+const (
+	IndexFulltext IndexKind = "FULLTEXT"
+	IndexSpatial  IndexKind = "SPATIAL"
+)
+
+// IndexOptions are the options of an index, like in KEY (a) USING HASH COMMENT 'fast'.
+type IndexOptions struct {
+	// Type is the data structure given by USING. Empty, if none was given.
+	Type IndexType
+	// KeyBlockSize is the KEY_BLOCK_SIZE as written in the SQL, like 8 or 8K. Might be nil.
+	KeyBlockSize *string
+	// Parser is the name of the full-text parser plugin given by WITH PARSER. Might be nil.
+	Parser *string
+	// Comment is the COMMENT of the index. Might be nil.
+	Comment *string
+	// Invisible is set, if the optimizer does not use the index.
+	Invisible bool
+}
+
+// IndexType is the data structure of an index.
+type IndexType string
+
+const (
+	IndexBTree IndexType = "BTREE"
+	IndexHash  IndexType = "HASH"
+)
+
+// IndexPart is a single column of an index, e.g. `name(20) DESC`.
+type IndexPart struct {
+	// Column is the name of the indexed column.
+	Column string
+	// Length is the number of leading characters that are indexed. Zero means that the whole value is indexed.
+	Length int
+	// Descending is set, if the index stores the column in descending order.
+	Descending bool
+}
+
+// View represents a CREATE VIEW statement.
+type View struct {
+	// Schema is the database of the view, see Table.Schema.
+	Schema string
+	Name   string
+	// OrReplace is set for CREATE OR REPLACE VIEW.
+	OrReplace bool
+	// Columns are the explicitly declared names of the columns. If empty, the names are taken from the query.
+	Columns []string
+	// Algorithm is the ALGORITHM option. Empty, if none was given.
+	Algorithm ViewAlgorithm
+	// Definer is the user given by DEFINER = 'user', as written in the SQL. Might be nil.
+	Definer *string
+	// Security is the SQL SECURITY option. Empty, if none was given.
+	Security SQLSecurity
+	// Definition is the SELECT statement of the view, as written in the SQL.
+	Definition string
+	// CheckOption is the level of the WITH CHECK OPTION clause. Empty, if there is no such clause.
+	CheckOption ViewCheckOption
+	// Dependencies are the names of all tables and views that the definition reads from,
+	// in the order of their first use. Names from another schema are qualified, like crm.customers.
+	Dependencies []string
+}
+
+// ViewAlgorithm is the ALGORITHM that MySQL uses to process a view.
+type ViewAlgorithm string
+
+const (
+	AlgorithmUndefined ViewAlgorithm = "UNDEFINED"
+	AlgorithmMerge     ViewAlgorithm = "MERGE"
+	AlgorithmTempTable ViewAlgorithm = "TEMPTABLE"
+)
+
+// SQLSecurity determines whose privileges are checked, when a view is accessed.
+type SQLSecurity string
+
+const (
+	SecurityDefiner SQLSecurity = "DEFINER"
+	SecurityInvoker SQLSecurity = "INVOKER"
+)
+
+// ViewCheckOption determines how WITH CHECK OPTION validates rows written through a view.
+type ViewCheckOption string
+
+const (
+	CheckOptionCascaded ViewCheckOption = "CASCADED"
+	CheckOptionLocal    ViewCheckOption = "LOCAL"
+)
+
+// Trigger represents a CREATE TRIGGER statement.
+type Trigger struct {
+	// Schema is the database of the trigger, which is always the database of its table.
+	Schema string
+	Name   string
+	// Definer is the user given by DEFINER = 'user', as written in the SQL. Might be nil.
+	Definer *string
+	// Timing is BEFORE or AFTER.
+	Timing TriggerTiming
+	// Event is the kind of row change, that activates the trigger.
+	Event TriggerEvent
+	// Table is the name of the table, that the trigger is defined on.
+	Table string
+	// Order places the trigger relative to another trigger with the same timing and event. Might be nil.
+	Order *TriggerOrder
+	// Body is the statement that is executed for each row, as written in the SQL.
+	Body string
+}
+
+// TriggerTiming determines whether a trigger runs before or after the row is changed.
+type TriggerTiming string
+
+const (
+	TriggerBefore TriggerTiming = "BEFORE"
+	TriggerAfter  TriggerTiming = "AFTER"
+)
+
+// TriggerEvent is the kind of row change, that activates a trigger.
+type TriggerEvent string
+
+const (
+	TriggerInsert TriggerEvent = "INSERT"
+	TriggerUpdate TriggerEvent = "UPDATE"
+	TriggerDelete TriggerEvent = "DELETE"
+)
+
+// TriggerOrder is a clause like FOLLOWS other_trigger.
+type TriggerOrder struct {
+	Position TriggerPosition
+	// Trigger is the name of the other trigger.
+	Trigger string
+}
+
+// TriggerPosition is FOLLOWS or PRECEDES.
+type TriggerPosition string
+
+const (
+	TriggerFollows  TriggerPosition = "FOLLOWS"
+	TriggerPrecedes TriggerPosition = "PRECEDES"
+)
+
+// Routine represents a CREATE PROCEDURE or CREATE FUNCTION statement.
+type Routine struct {
+	// Schema is the database of the routine, see Table.Schema.
+	Schema string
+	// Kind is PROCEDURE or FUNCTION.
+	Kind RoutineKind
+	Name string
+	// Definer is the user given by DEFINER = 'user', as written in the SQL. Might be nil.
+	Definer *string
+	// Parameters are the declared parameters in their order.
+	Parameters []RoutineParameter
+	// Returns is the type returned by a FUNCTION. Nil for procedures.
+	Returns *DataType
+	// Comment is the COMMENT characteristic. Might be nil.
+	Comment *string
+	// Deterministic is set, if the routine always produces the same result for the same parameters.
+	Deterministic bool
+	// DataAccess describes how the routine uses data. Empty, if it was not declared.
+	DataAccess RoutineDataAccess
+	// Security is the SQL SECURITY characteristic. Empty, if none was given.
+	Security SQLSecurity
+	// Body is the statement that is executed when the routine is called, as written in the SQL.
+	Body string
+}
+
+// RoutineKind distinguishes stored procedures from stored functions.
+type RoutineKind string
+
+const (
+	RoutineProcedure RoutineKind = "PROCEDURE"
+	RoutineFunction  RoutineKind = "FUNCTION"
+)
+
+// RoutineParameter is a single parameter of a stored procedure or function.
+type RoutineParameter struct {
+	// Direction is IN, OUT or INOUT for procedures. It is empty for functions and for
+	// procedure parameters without explicit direction, which are IN parameters.
+	Direction ParameterDirection
+	Name      string
+	Type      DataType
+}
+
+// ParameterDirection determines whether a value is passed into or out of a procedure.
+type ParameterDirection string
+
+const (
+	ParameterIn    ParameterDirection = "IN"
+	ParameterOut   ParameterDirection = "OUT"
+	ParameterInOut ParameterDirection = "INOUT"
+)
+
+// RoutineDataAccess is the characteristic, that tells how a routine uses data.
+type RoutineDataAccess string
+
+const (
+	DataContainsSQL RoutineDataAccess = "CONTAINS SQL"
+	DataNoSQL       RoutineDataAccess = "NO SQL"
+	DataReadsSQL    RoutineDataAccess = "READS SQL DATA"
+	DataModifiesSQL RoutineDataAccess = "MODIFIES SQL DATA"
+)
+
 // AlterAddColumn represents an ALTER TABLE 'Table' ADD COLUMN statement.
 type AlterAddColumn struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
 	// Table is the name of the table to which the column is added.
 	Table string
 	// Column is the column to add.
@@ -75,38 +433,167 @@ type AlterAddColumn struct {
 
 // AlterDropColumn describes an ALTER TABLE 'table' DROP COLUMN 'column'.
 type AlterDropColumn struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
 	// Table is the name of the table from which the column is removed.
 	Table string
 	// Column is the name of the column that will be removed.
 	Column string
 }
 
-// AlterAddIndex describes a CREATE INDEX 'name' ON 'table' ('column') statement.
+// AlterModifyColumn describes an ALTER TABLE 'table' MODIFY COLUMN statement, which replaces
+// the definition of a column, but keeps its name.
+type AlterModifyColumn struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
+	// Table is the name of the table that contains the column.
+	Table string
+	// Column is the new definition of the column. Its name identifies the column to modify.
+	Column Column
+	// First is true when the column should be moved to the front of the table.
+	First bool
+	// After is set when the column should be moved after the given column name in the table.
+	After *string
+}
+
+// AlterChangeColumn describes an ALTER TABLE 'table' CHANGE COLUMN 'old' 'new' statement, which
+// replaces the definition of a column and may rename it.
+type AlterChangeColumn struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
+	// Table is the name of the table that contains the column.
+	Table string
+	// OldName is the current name of the column.
+	OldName string
+	// Column is the new definition of the column, including its new name.
+	Column Column
+	// First is true when the column should be moved to the front of the table.
+	First bool
+	// After is set when the column should be moved after the given column name in the table.
+	After *string
+}
+
+// AlterRenameColumn describes an ALTER TABLE 'table' RENAME COLUMN 'old' TO 'new' statement.
+type AlterRenameColumn struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
+	// Table is the name of the table that contains the column.
+	Table string
+	// OldName is the current name of the column.
+	OldName string
+	// NewName is the name of the column after the statement.
+	NewName string
+}
+
+// AlterAddIndex describes a CREATE INDEX 'name' ON 'table' ('column', ...) statement.
 type AlterAddIndex struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
 	// Table is the name of the table to which the statement is added.
 	Table string
-	// Name is the name of the new index.
+	// Name is the name of the new index. Empty, if MySQL names it, see Index.Name.
 	Name string
-	// Column is the name of the column the index will be applied to.
-	Column string
+	// Parts are the columns the index will be applied to.
+	Parts []IndexPart
 	// Unique is set, if the index is UNIQUE.
 	Unique bool
+	// Kind is FULLTEXT or SPATIAL. Empty for a plain or unique index.
+	Kind IndexKind
+	// Options are the options of the new index.
+	Options IndexOptions
 }
 
 // AlterDropIndex describes a DROP INDEX 'name' ON 'table' or a ALTER TABLE 'table' DROP INDEX 'index' statement.
 type AlterDropIndex struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
 	// Table is the name of the table from which the index will be removed.
 	Table string
 	// Index is the name of the index that should be removed.
 	Index string
 }
 
-// AlterStatement might be ADD COLUMN, DROP COLUMN, ADD INDEX, DROP INDEX.
-// It can be applied to a table to perform the corresponding operation.
+// AlterAddPrimaryKey describes an ALTER TABLE 'table' ADD PRIMARY KEY ('column', ...) statement.
+type AlterAddPrimaryKey struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
+	// Table is the name of the table that gets the primary key.
+	Table string
+	// PrimaryKey is the new primary key.
+	PrimaryKey PrimaryKeyConstraint
+}
+
+// AlterDropPrimaryKey describes an ALTER TABLE 'table' DROP PRIMARY KEY statement.
+type AlterDropPrimaryKey struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
+	// Table is the name of the table from which the primary key is removed.
+	Table string
+}
+
+// AlterAddForeignKey describes an ALTER TABLE 'table' ADD FOREIGN KEY statement.
+type AlterAddForeignKey struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
+	// Table is the name of the constraining table.
+	Table string
+	// ForeignKey is the new constraint.
+	ForeignKey ForeignKeyConstraint
+}
+
+// AlterDropForeignKey describes an ALTER TABLE 'table' DROP FOREIGN KEY 'name' statement.
+type AlterDropForeignKey struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
+	// Table is the name of the table from which the foreign key is removed.
+	Table string
+	// Name is the name of the constraint.
+	Name string
+}
+
+// AlterAddCheck describes an ALTER TABLE 'table' ADD CHECK (expression) statement.
+type AlterAddCheck struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
+	// Table is the name of the table that gets the constraint.
+	Table string
+	// Check is the new constraint.
+	Check CheckConstraint
+}
+
+// AlterDropConstraint describes an ALTER TABLE 'table' DROP CONSTRAINT 'name' or DROP CHECK 'name' statement.
+type AlterDropConstraint struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
+	// Table is the name of the table from which the constraint is removed.
+	Table string
+	// Name is the name of the constraint.
+	Name string
+	// Check is set for DROP CHECK, which only drops CHECK constraints. DROP CONSTRAINT
+	// also drops FOREIGN KEY and UNIQUE constraints.
+	Check bool
+}
+
+// AlterTableOptions describes an ALTER TABLE 'table' ENGINE=InnoDB COMMENT='...' statement, which changes table options.
+type AlterTableOptions struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
+	// Table is the name of the table whose options are changed.
+	Table string
+	// Options are the changed options. Options that are nil keep their value. An empty Comment removes
+	// the comment, and each of Others replaces the option with the same name.
+	Options TableOptions
+}
+
+// AlterStatement might be ADD COLUMN, DROP COLUMN, MODIFY COLUMN, CHANGE COLUMN, RENAME COLUMN, ADD INDEX, DROP INDEX,
+// add or drop a constraint or change the table options. It can be applied to a table to perform the corresponding operation.
 type AlterStatement interface {
+	// SchemaName returns the database of the Table that this statement wants to modify.
+	SchemaName() string
 	// TableName returns the name of the Table that this statement wants to modify.
 	TableName() string
-	// ApplyTo applies the alteration to the given table.
+	// ApplyTo applies the alteration to the given table. The table gets new slices and pointers,
+	// so that copies of it, which share them, are not changed.
 	// Returns an error if applying failed.
 	ApplyTo(table *Table) error
 }
@@ -120,11 +607,34 @@ func (e DropErrorNotFound) Error() string {
 	return fmt.Sprintf("%s could not be dropped because it was not present", e.property)
 }
 
+// AlterErrorNotFound signifies that a certain thing could not be changed, because it is missing.
+type AlterErrorNotFound struct {
+	property string
+}
+
+func (e AlterErrorNotFound) Error() string {
+	return fmt.Sprintf("%s could not be altered because it was not present", e.property)
+}
+
+// AddErrorExists signifies that a certain thing could not be added, because it is already present.
+type AddErrorExists struct {
+	property string
+}
+
+func (e AddErrorExists) Error() string {
+	return fmt.Sprintf("%s could not be added because it was already present", e.property)
+}
+
+func (a AlterAddColumn) SchemaName() string {
+	return a.Schema
+}
+
 func (a AlterAddColumn) TableName() string {
 	return a.Table
 }
 
 func (a AlterAddColumn) ApplyTo(table *Table) error {
+	*table = table.Clone()
 	insertAt := len(table.Columns)
 
 	if a.First {
@@ -132,82 +642,475 @@ func (a AlterAddColumn) ApplyTo(table *Table) error {
 	}
 
 	if a.After != nil {
-		for i, col := range table.Columns {
-			if col.Name == *a.After {
-				insertAt = i + 1
-			}
+		if i := table.columnIndex(*a.After); i >= 0 {
+			insertAt = i + 1
 		}
 	}
 
-	if len(table.Columns) == insertAt {
-		// Append
-		table.Columns = append(table.Columns, a.Column)
-	} else {
-		// Index points somewhere in the middle
-		table.Columns = append(table.Columns[:insertAt+1], table.Columns[insertAt:]...)
-		table.Columns[insertAt] = a.Column
-	}
+	table.Columns = append(table.Columns[:insertAt], append([]Column{a.Column.Clone()}, table.Columns[insertAt:]...)...)
 
 	return nil
 }
 
+func (a AlterDropColumn) SchemaName() string {
+	return a.Schema
+}
+
 func (a AlterDropColumn) TableName() string {
 	return a.Table
 }
 
 func (a AlterDropColumn) ApplyTo(table *Table) error {
-	index := -1
+	index := table.columnIndex(a.Column)
+	if index < 0 {
+		return DropErrorNotFound{a.Column}
+	}
 
-	for i, col := range table.Columns {
-		if col.Name == a.Column {
-			index = i
+	*table = table.Clone()
+	table.Columns = append(table.Columns[:index], table.Columns[index+1:]...)
 
-			break
+	return nil
+}
+
+func (a AlterModifyColumn) SchemaName() string {
+	return a.Schema
+}
+
+func (a AlterModifyColumn) TableName() string {
+	return a.Table
+}
+
+func (a AlterModifyColumn) ApplyTo(table *Table) error {
+	return AlterChangeColumn{
+		Schema:  a.Schema,
+		Table:   a.Table,
+		OldName: a.Column.Name,
+		Column:  a.Column,
+		First:   a.First,
+		After:   a.After,
+	}.ApplyTo(table)
+}
+
+func (a AlterChangeColumn) SchemaName() string {
+	return a.Schema
+}
+
+func (a AlterChangeColumn) TableName() string {
+	return a.Table
+}
+
+func (a AlterChangeColumn) ApplyTo(table *Table) error {
+	index := table.columnIndex(a.OldName)
+	if index < 0 {
+		return AlterErrorNotFound{a.OldName}
+	}
+
+	// Like in MySQL, the indexes of a column level PRIMARY KEY or UNIQUE constraint stay.
+	column := a.Column.Clone()
+	column.PrimaryKey = column.PrimaryKey || table.Columns[index].PrimaryKey
+	column.Unique = column.Unique || table.Columns[index].Unique
+
+	// Take the column out and insert its new definition at the new position,
+	// which stays the old one, unless FIRST or AFTER is given.
+	changed := table.Clone()
+	changed.Columns = append(changed.Columns[:index], changed.Columns[index+1:]...)
+
+	if a.First {
+		index = 0
+	}
+
+	if a.After != nil {
+		index = changed.columnIndex(*a.After)
+		if index < 0 {
+			return AlterErrorNotFound{*a.After}
 		}
+
+		index++
 	}
 
+	changed.Columns = append(changed.Columns[:index], append([]Column{column}, changed.Columns[index:]...)...)
+
+	if a.OldName != a.Column.Name {
+		renameColumnReferences(&changed, a.OldName, a.Column.Name)
+	}
+
+	*table = changed
+
+	return nil
+}
+
+func (a AlterRenameColumn) SchemaName() string {
+	return a.Schema
+}
+
+func (a AlterRenameColumn) TableName() string {
+	return a.Table
+}
+
+func (a AlterRenameColumn) ApplyTo(table *Table) error {
+	index := table.columnIndex(a.OldName)
 	if index < 0 {
-		return DropErrorNotFound{a.Column}
+		return AlterErrorNotFound{a.OldName}
 	}
 
-	table.Columns = append(table.Columns[:index], table.Columns[index+1:]...)
+	*table = table.Clone()
+	table.Columns[index].Name = a.NewName
+	renameColumnReferences(table, a.OldName, a.NewName)
 
 	return nil
 }
 
+// renameColumnReferences updates the indexes and foreign keys of a table after a column
+// was renamed, like MySQL does. The table must not share its slices with other tables.
+func renameColumnReferences(table *Table, oldName, newName string) {
+	renameParts := func(parts []IndexPart) {
+		for i := range parts {
+			if strings.EqualFold(parts[i].Column, oldName) {
+				parts[i].Column = newName
+			}
+		}
+	}
+
+	if table.PrimaryKey != nil {
+		renameParts(table.PrimaryKey.Parts)
+	}
+
+	for _, unique := range table.UniqueConstraints {
+		renameParts(unique.Parts)
+	}
+
+	for _, key := range table.Keys {
+		renameParts(key.Parts)
+	}
+
+	for _, foreignKey := range table.ForeignKeys {
+		for i := range foreignKey.Columns {
+			if strings.EqualFold(foreignKey.Columns[i], oldName) {
+				foreignKey.Columns[i] = newName
+			}
+		}
+	}
+}
+
+func (a AlterAddIndex) SchemaName() string {
+	return a.Schema
+}
+
 func (a AlterAddIndex) TableName() string {
 	return a.Table
 }
 
 func (a AlterAddIndex) ApplyTo(table *Table) error {
-	table.Keys = append(table.Keys, Key{
-		Name:     &a.Name,
-		OnColumn: a.Column,
-	})
+	*table = table.Clone()
+
+	// The existing indexes keep their names, the new one is named after them.
+	table.nameIndexes()
+
+	var name *string
+	if a.Name != "" {
+		name = &a.Name
+	}
+
+	// A unique index is the same as a table level UNIQUE constraint.
+	if a.Unique {
+		table.UniqueConstraints = append(table.UniqueConstraints, UniqueConstraint{
+			Name:    name,
+			Parts:   a.Parts,
+			Options: a.Options,
+		}.Clone())
+	} else {
+		table.Keys = append(table.Keys, Key{
+			Name:    name,
+			Kind:    a.Kind,
+			Parts:   a.Parts,
+			Options: a.Options,
+		}.Clone())
+	}
 
 	return nil
 }
 
+func (a AlterDropIndex) SchemaName() string {
+	return a.Schema
+}
+
 func (a AlterDropIndex) TableName() string {
 	return a.Table
 }
 
 func (a AlterDropIndex) ApplyTo(table *Table) error {
+	*table = table.Clone()
 	index := -1
 
+	// The remaining indexes keep their names.
+	table.nameIndexes()
+
 	for i, key := range table.Keys {
-		if key.Name != nil && *key.Name == a.Index {
+		if strings.EqualFold(*key.Name, a.Index) {
 			index = i
 
 			break
 		}
 	}
 
-	if index < 0 {
-		return DropErrorNotFound{a.Index}
+	if index >= 0 {
+		table.Keys = append(table.Keys[:index], table.Keys[index+1:]...)
+
+		return nil
+	}
+
+	// Unique constraints are indexes, too.
+	for i, unique := range table.UniqueConstraints {
+		if strings.EqualFold(*unique.Name, a.Index) {
+			table.UniqueConstraints = append(table.UniqueConstraints[:i], table.UniqueConstraints[i+1:]...)
+
+			return nil
+		}
+	}
+
+	// The index of a column level UNIQUE constraint is named after the column.
+	for i, column := range table.Columns {
+		if column.Unique && strings.EqualFold(column.Name, a.Index) {
+			table.Columns[i].Unique = false
+
+			return nil
+		}
+	}
+
+	return DropErrorNotFound{a.Index}
+}
+
+func (a AlterAddPrimaryKey) SchemaName() string {
+	return a.Schema
+}
+
+func (a AlterAddPrimaryKey) TableName() string {
+	return a.Table
+}
+
+func (a AlterAddPrimaryKey) ApplyTo(table *Table) error {
+	if hasPrimaryKey(table) {
+		return AddErrorExists{"PRIMARY KEY"}
+	}
+
+	*table = table.Clone()
+	primaryKey := a.PrimaryKey.Clone()
+	table.PrimaryKey = &primaryKey
+
+	return nil
+}
+
+func (a AlterDropPrimaryKey) SchemaName() string {
+	return a.Schema
+}
+
+func (a AlterDropPrimaryKey) TableName() string {
+	return a.Table
+}
+
+func (a AlterDropPrimaryKey) ApplyTo(table *Table) error {
+	if !hasPrimaryKey(table) {
+		return DropErrorNotFound{"PRIMARY KEY"}
+	}
+
+	*table = table.Clone()
+	table.PrimaryKey = nil
+
+	for i := range table.Columns {
+		table.Columns[i].PrimaryKey = false
+	}
+
+	return nil
+}
+
+// hasPrimaryKey returns true, if the table has a table or column level PRIMARY KEY.
+func hasPrimaryKey(table *Table) bool {
+	if table.PrimaryKey != nil {
+		return true
+	}
+
+	for _, column := range table.Columns {
+		if column.PrimaryKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a AlterAddForeignKey) SchemaName() string {
+	return a.Schema
+}
+
+func (a AlterAddForeignKey) TableName() string {
+	return a.Table
+}
+
+func (a AlterAddForeignKey) ApplyTo(table *Table) error {
+	*table = table.Clone()
+	table.ForeignKeys = append(table.ForeignKeys, a.ForeignKey.Clone())
+
+	return nil
+}
+
+func (a AlterDropForeignKey) SchemaName() string {
+	return a.Schema
+}
+
+func (a AlterDropForeignKey) TableName() string {
+	return a.Table
+}
+
+func (a AlterDropForeignKey) ApplyTo(table *Table) error {
+	*table = table.Clone()
+
+	for i, foreignKey := range table.ForeignKeys {
+		if foreignKey.Name != nil && strings.EqualFold(*foreignKey.Name, a.Name) {
+			table.ForeignKeys = append(table.ForeignKeys[:i], table.ForeignKeys[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return DropErrorNotFound{a.Name}
+}
+
+func (a AlterAddCheck) SchemaName() string {
+	return a.Schema
+}
+
+func (a AlterAddCheck) TableName() string {
+	return a.Table
+}
+
+func (a AlterAddCheck) ApplyTo(table *Table) error {
+	*table = table.Clone()
+	table.Checks = append(table.Checks, a.Check.Clone())
+
+	return nil
+}
+
+func (a AlterTableOptions) SchemaName() string {
+	return a.Schema
+}
+
+func (a AlterTableOptions) TableName() string {
+	return a.Table
+}
+
+func (a AlterTableOptions) ApplyTo(table *Table) error {
+	*table = table.Clone()
+	options := a.Options.Clone()
+
+	if options.Engine != nil {
+		table.Options.Engine = options.Engine
+	}
+
+	if options.AutoIncrement != nil {
+		table.Options.AutoIncrement = options.AutoIncrement
+	}
+
+	if options.CharacterSet != nil {
+		table.Options.CharacterSet = options.CharacterSet
+	}
+
+	if options.Collate != nil {
+		table.Options.Collate = options.Collate
+	}
+
+	if options.Comment != nil {
+		table.Options.Comment = options.Comment
+		if *options.Comment == "" {
+			table.Options.Comment = nil
+		}
 	}
 
-	table.Keys = append(table.Keys[:index], table.Keys[index+1:]...)
+	if options.RowFormat != nil {
+		table.Options.RowFormat = options.RowFormat
+	}
+
+	for _, other := range options.Others {
+		replaced := false
+
+		for i, old := range table.Options.Others {
+			if strings.EqualFold(TableOptionName(old), TableOptionName(other)) {
+				table.Options.Others[i] = other
+				replaced = true
+
+				break
+			}
+		}
+
+		if !replaced {
+			table.Options.Others = append(table.Options.Others, other)
+		}
+	}
 
 	return nil
 }
+
+// TableOptionName returns the name of an option in TableOptions.Others, e.g. STATS_PERSISTENT
+// for STATS_PERSISTENT=0.
+func TableOptionName(option string) string {
+	if i := strings.IndexAny(option, "= "); i >= 0 {
+		return option[:i]
+	}
+
+	return option
+}
+
+func (a AlterDropConstraint) SchemaName() string {
+	return a.Schema
+}
+
+func (a AlterDropConstraint) TableName() string {
+	return a.Table
+}
+
+func (a AlterDropConstraint) ApplyTo(table *Table) error {
+	*table = table.Clone()
+
+	isNamed := func(name *string) bool {
+		return name != nil && strings.EqualFold(*name, a.Name)
+	}
+
+	for i, check := range table.Checks {
+		if isNamed(check.Name) {
+			table.Checks = append(table.Checks[:i], table.Checks[i+1:]...)
+
+			return nil
+		}
+	}
+
+	for i, column := range table.Columns {
+		for j, check := range column.Checks {
+			if isNamed(check.Name) {
+				table.Columns[i].Checks = append(column.Checks[:j], column.Checks[j+1:]...)
+
+				return nil
+			}
+		}
+	}
+
+	if a.Check {
+		return DropErrorNotFound{a.Name}
+	}
+
+	for i, foreignKey := range table.ForeignKeys {
+		if isNamed(foreignKey.Name) {
+			table.ForeignKeys = append(table.ForeignKeys[:i], table.ForeignKeys[i+1:]...)
+
+			return nil
+		}
+	}
+
+	for i, unique := range table.UniqueConstraints {
+		if isNamed(unique.Name) {
+			table.UniqueConstraints = append(table.UniqueConstraints[:i], table.UniqueConstraints[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return DropErrorNotFound{a.Name}
+}
@@ -15,7 +15,9 @@
 package ddl_test
 
 import (
+	"errors"
 	"github.com/golangee/sql/ddl"
+	"strings"
 	"testing"
 )
 
@@ -65,15 +67,264 @@ func TestAlterAddColumn_Apply(t *testing.T) {
 
 func TestAlterAddIndex_Apply(t *testing.T) {
 	table := ddl.Table{}
-	if err := (ddl.AlterAddIndex{Column: "A"}.ApplyTo(&table)); err != nil {
+	parts := []ddl.IndexPart{{Column: "A"}, {Column: "B", Length: 10, Descending: true}}
+	if err := (ddl.AlterAddIndex{Parts: parts}.ApplyTo(&table)); err != nil {
 		t.Fatal(err)
 	}
 
-	if len(table.Keys) < 1 || table.Keys[0].OnColumn != "A" {
+	if len(table.Keys) < 1 || len(table.Keys[0].Parts) != 2 || table.Keys[0].Parts[1] != parts[1] {
 		t.Fatalf("Failed to insert key")
 	}
 }
 
+func TestAlterAddIndex_ApplyUnique(t *testing.T) {
+	table := ddl.Table{}
+	add := ddl.AlterAddIndex{Name: "idx", Parts: []ddl.IndexPart{{Column: "A"}}, Unique: true}
+	if err := add.ApplyTo(&table); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(table.Keys) != 0 || len(table.UniqueConstraints) != 1 || *table.UniqueConstraints[0].Name != "idx" {
+		t.Fatalf("Failed to insert unique constraint")
+	}
+
+	if err := (ddl.AlterDropIndex{Index: "idx"}.ApplyTo(&table)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(table.UniqueConstraints) != 0 {
+		t.Fatalf("Failed to drop unique constraint")
+	}
+}
+
+func TestAlterAddIndex_ApplyUnnamed(t *testing.T) {
+	table := ddl.Table{Keys: []ddl.Key{{Parts: []ddl.IndexPart{{Column: "A"}}}}}
+	if err := (ddl.AlterAddIndex{Parts: []ddl.IndexPart{{Column: "A"}, {Column: "B"}}}.ApplyTo(&table)); err != nil {
+		t.Fatal(err)
+	}
+
+	// MySQL appends _2 to the name of the second index on A, which keeps it when the first is dropped.
+	if err := (ddl.AlterDropIndex{Index: "A"}.ApplyTo(&table)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := table.Index("A_2"); !ok || len(table.Keys) != 1 {
+		t.Fatalf("Expected the index A_2 to remain, but got %v", table.Indexes())
+	}
+}
+
+func TestAlterChangeColumn_Apply(t *testing.T) {
+	after := "C"
+	table := ddl.Table{
+		Columns:     []ddl.Column{{Name: "A"}, {Name: "B"}, {Name: "C"}},
+		PrimaryKey:  &ddl.PrimaryKeyConstraint{Parts: []ddl.IndexPart{{Column: "A"}}},
+		ForeignKeys: []ddl.ForeignKeyConstraint{{Columns: []string{"A"}, ReferenceTable: "T", ReferenceColumns: []string{"A"}}},
+	}
+
+	change := ddl.AlterChangeColumn{OldName: "A", Column: ddl.Column{Name: "X", NotNull: true}, After: &after}
+	if err := change.ApplyTo(&table); err != nil {
+		t.Fatal(err)
+	}
+
+	if names := columnNames(table); names != "B,C,X" || !table.Columns[2].NotNull {
+		t.Fatalf("Failed to change column, got %s", names)
+	}
+
+	// Only the constraining columns are renamed, not the referenced ones.
+	if table.PrimaryKey.Parts[0].Column != "X" || table.ForeignKeys[0].Columns[0] != "X" ||
+		table.ForeignKeys[0].ReferenceColumns[0] != "A" {
+		t.Fatalf("Failed to rename the references to the column")
+	}
+
+	if err := (ddl.AlterChangeColumn{OldName: "A", Column: ddl.Column{Name: "Y"}}.ApplyTo(&table)); err == nil {
+		t.Fatalf("Expected an error for a missing column")
+	}
+}
+
+func TestAlterModifyColumn_Apply(t *testing.T) {
+	table := ddl.Table{
+		Columns: []ddl.Column{{Name: "A"}, {Name: "B"}, {Name: "C"}},
+	}
+
+	modify := ddl.AlterModifyColumn{Column: ddl.Column{Name: "B", Type: ddl.DataType{Name: "INT"}}}
+	if err := modify.ApplyTo(&table); err != nil {
+		t.Fatal(err)
+	}
+
+	if names := columnNames(table); names != "A,B,C" || table.Columns[1].Type.Name != "INT" {
+		t.Fatalf("Failed to modify column in place, got %s", names)
+	}
+
+	modify = ddl.AlterModifyColumn{Column: ddl.Column{Name: "C"}, First: true}
+	if err := modify.ApplyTo(&table); err != nil {
+		t.Fatal(err)
+	}
+
+	if names := columnNames(table); names != "C,A,B" {
+		t.Fatalf("Failed to move column to the front, got %s", names)
+	}
+
+	missing := "Z"
+	if err := (ddl.AlterModifyColumn{Column: ddl.Column{Name: "A"}, After: &missing}.ApplyTo(&table)); err == nil {
+		t.Fatalf("Expected an error for a missing AFTER column")
+	}
+
+	// MODIFY keeps the PRIMARY KEY, like in MySQL.
+	table.Columns[0].PrimaryKey = true
+	if err := (ddl.AlterModifyColumn{Column: ddl.Column{Name: "C", NotNull: true}}.ApplyTo(&table)); err != nil {
+		t.Fatal(err)
+	}
+
+	if !table.Columns[0].PrimaryKey || !table.Columns[0].NotNull {
+		t.Fatalf("Expected the column to stay the primary key")
+	}
+}
+
+func TestAlterRenameColumn_Apply(t *testing.T) {
+	keyName := "idx"
+	table := ddl.Table{
+		Columns: []ddl.Column{{Name: "A"}, {Name: "B"}},
+		Keys:    []ddl.Key{{Name: &keyName, Parts: []ddl.IndexPart{{Column: "B"}, {Column: "A"}}}},
+	}
+
+	if err := (ddl.AlterRenameColumn{OldName: "B", NewName: "X"}.ApplyTo(&table)); err != nil {
+		t.Fatal(err)
+	}
+
+	if names := columnNames(table); names != "A,X" || table.Keys[0].Parts[0].Column != "X" {
+		t.Fatalf("Failed to rename column, got %s", names)
+	}
+
+	if err := (ddl.AlterRenameColumn{OldName: "B", NewName: "Y"}.ApplyTo(&table)); err == nil {
+		t.Fatalf("Expected an error for a missing column")
+	}
+}
+
+func TestAlterPrimaryKey_Apply(t *testing.T) {
+	table := ddl.Table{
+		Columns: []ddl.Column{{Name: "A", PrimaryKey: true}, {Name: "B"}},
+	}
+
+	add := ddl.AlterAddPrimaryKey{PrimaryKey: ddl.PrimaryKeyConstraint{Parts: []ddl.IndexPart{{Column: "B"}}}}
+	if err := add.ApplyTo(&table); err == nil {
+		t.Fatalf("Expected an error for a second primary key")
+	}
+
+	if err := (ddl.AlterDropPrimaryKey{}.ApplyTo(&table)); err != nil {
+		t.Fatal(err)
+	}
+
+	if table.Columns[0].PrimaryKey {
+		t.Fatalf("Failed to drop the column level primary key")
+	}
+
+	if err := add.ApplyTo(&table); err != nil {
+		t.Fatal(err)
+	}
+
+	if table.PrimaryKey == nil || table.PrimaryKey.Parts[0].Column != "B" {
+		t.Fatalf("Failed to add the primary key")
+	}
+
+	if err := (ddl.AlterDropPrimaryKey{}.ApplyTo(&table)); err != nil || table.PrimaryKey != nil {
+		t.Fatalf("Failed to drop the table level primary key: %v", err)
+	}
+
+	if err := (ddl.AlterDropPrimaryKey{}.ApplyTo(&table)); err == nil {
+		t.Fatalf("Expected an error for a missing primary key")
+	}
+}
+
+func TestAlterConstraints_Apply(t *testing.T) {
+	fk, check, unique, columnCheck := "fk", "check", "unique", "columnCheck"
+	table := ddl.Table{
+		Columns:           []ddl.Column{{Name: "A", Checks: []ddl.CheckConstraint{{Name: &columnCheck}}}},
+		UniqueConstraints: []ddl.UniqueConstraint{{Name: &unique, Parts: []ddl.IndexPart{{Column: "A"}}}},
+	}
+
+	statements := []ddl.AlterStatement{
+		ddl.AlterAddForeignKey{ForeignKey: ddl.ForeignKeyConstraint{Name: &fk, Columns: []string{"A"}}},
+		ddl.AlterAddCheck{Check: ddl.CheckConstraint{Name: &check, Expression: "A > 0"}},
+	}
+
+	for _, statement := range statements {
+		if err := statement.ApplyTo(&table); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(table.ForeignKeys) != 1 || len(table.Checks) != 1 {
+		t.Fatalf("Failed to add the constraints")
+	}
+
+	// DROP CHECK must not drop other kinds of constraints.
+	if err := (ddl.AlterDropConstraint{Name: "unique", Check: true}.ApplyTo(&table)); err == nil {
+		t.Fatalf("Expected an error for dropping a UNIQUE constraint by DROP CHECK")
+	}
+
+	// Names of constraints are case-insensitive.
+	statements = []ddl.AlterStatement{
+		ddl.AlterDropForeignKey{Name: "FK"},
+		ddl.AlterDropConstraint{Name: "Check", Check: true},
+		ddl.AlterDropConstraint{Name: "COLUMNCHECK"},
+		ddl.AlterDropConstraint{Name: "Unique"},
+	}
+
+	for _, statement := range statements {
+		if err := statement.ApplyTo(&table); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(table.ForeignKeys) != 0 || len(table.Checks) != 0 || len(table.Columns[0].Checks) != 0 ||
+		len(table.UniqueConstraints) != 0 {
+		t.Fatalf("Failed to drop the constraints")
+	}
+
+	var notFound ddl.DropErrorNotFound
+	if err := (ddl.AlterDropForeignKey{Name: "fk"}.ApplyTo(&table)); !errors.As(err, &notFound) {
+		t.Fatalf("Expected DropErrorNotFound, got %v", err)
+	}
+}
+
+// Statements must not change the backing arrays that a table shares with its copies.
+func TestAlterStatements_ApplyToCopy(t *testing.T) {
+	after := "A"
+	original := ddl.Table{
+		Columns: make([]ddl.Column, 0, 10),
+		Keys:    []ddl.Key{{Parts: []ddl.IndexPart{{Column: "B"}}}},
+	}
+	original.Columns = append(original.Columns, ddl.Column{Name: "A"}, ddl.Column{Name: "B"})
+
+	statements := []ddl.AlterStatement{
+		ddl.AlterAddColumn{Column: ddl.Column{Name: "X"}, After: &after},
+		ddl.AlterDropColumn{Column: "A"},
+		ddl.AlterRenameColumn{OldName: "B", NewName: "Y"},
+		ddl.AlterChangeColumn{OldName: "B", Column: ddl.Column{Name: "Z"}},
+	}
+
+	for _, statement := range statements {
+		table := original
+
+		if err := statement.ApplyTo(&table); err != nil {
+			t.Fatal(err)
+		}
+
+		if names := columnNames(original); names != "A,B" || original.Keys[0].Parts[0].Column != "B" {
+			t.Fatalf("%T changed the original table to %s", statement, names)
+		}
+	}
+}
+
+func columnNames(table ddl.Table) string {
+	var names []string
+	for _, column := range table.Columns {
+		names = append(names, column.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
 func TestAlterDropColumn_Apply(t *testing.T) {
 	table := ddl.Table{
 		Columns: []ddl.Column{
@@ -105,7 +356,7 @@ func TestAlterDropIndex_Apply(t *testing.T) {
 	indexName := "idx"
 	table := ddl.Table{
 		Keys: []ddl.Key{
-			{OnColumn: "A", Name: &indexName},
+			{Parts: []ddl.IndexPart{{Column: "A"}}, Name: &indexName},
 		},
 	}
 
@@ -117,3 +368,25 @@ func TestAlterDropIndex_Apply(t *testing.T) {
 		t.Fatalf("Failed to drop key")
 	}
 }
+
+func TestDataType_String(t *testing.T) {
+	length, precision, scale := 255, 10, 2
+	charset := "utf8mb4"
+
+	tests := []struct {
+		dataType ddl.DataType
+		expected string
+	}{
+		{ddl.DataType{Name: "INT"}, "INT"},
+		{ddl.DataType{Name: "VARCHAR", Length: &length, CharacterSet: &charset}, "VARCHAR(255) CHARACTER SET utf8mb4"},
+		{ddl.DataType{Name: "DECIMAL", Precision: &precision, Scale: &scale, Unsigned: true}, "DECIMAL(10,2) UNSIGNED"},
+		{ddl.DataType{Name: "DECIMAL", Precision: &precision}, "DECIMAL(10)"},
+		{ddl.DataType{Name: "ENUM", Values: []string{"it's", "b"}}, "ENUM('it''s','b')"},
+	}
+
+	for _, test := range tests {
+		if actual := test.dataType.String(); actual != test.expected {
+			t.Errorf("Expected %s, but got %s", test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+// Partitioning describes how the rows of a table are distributed, like in
+// PARTITION BY RANGE (YEAR(Created)) (PARTITION p2020 VALUES LESS THAN (2021), ...).
+type Partitioning struct {
+	// Function decides which partition a row belongs to.
+	Function PartitionFunction
+	// Count is the number of partitions given by PARTITIONS n. Might be nil.
+	Count *int
+	// Subpartitioning decides which subpartition of a partition a row belongs to. Might be nil.
+	Subpartitioning *PartitionFunction
+	// SubpartitionCount is the number of subpartitions given by SUBPARTITIONS n. Might be nil.
+	SubpartitionCount *int
+	// Partitions are the explicitly defined partitions in their order.
+	Partitions []Partition
+}
+
+// PartitionFunction is the method and expression of a PARTITION BY or SUBPARTITION BY clause.
+type PartitionFunction struct {
+	Method PartitionMethod
+	// Linear is set for LINEAR HASH and LINEAR KEY.
+	Linear bool
+	// Expression is the partitioning expression of HASH, RANGE and LIST, as written in the SQL.
+	// It is empty, if Columns are used instead.
+	Expression string
+	// Columns are the columns of KEY, RANGE COLUMNS and LIST COLUMNS.
+	Columns []string
+	// Algorithm is the hashing algorithm of KEY, like in KEY ALGORITHM=2 (Id). Might be nil.
+	Algorithm *int
+}
+
+// PartitionMethod is the kind of a partitioning function.
+type PartitionMethod string
+
+const (
+	PartitionHash  PartitionMethod = "HASH"
+	PartitionKey   PartitionMethod = "KEY"
+	PartitionRange PartitionMethod = "RANGE"
+	PartitionList  PartitionMethod = "LIST"
+)
+
+// Partition is a single PARTITION definition.
+type Partition struct {
+	Name string
+	// LessThan are the upper bounds of a RANGE partition, as written in the SQL, like 2021 or MAXVALUE.
+	// There is one bound per column for RANGE COLUMNS.
+	LessThan []string
+	// In are the values of a LIST partition, as written in the SQL.
+	// For LIST COLUMNS with multiple columns, each value is a tuple like (1, 'a').
+	In []string
+	// Options are the storage options of the partition.
+	Options PartitionOptions
+	// Subpartitions are the explicitly defined subpartitions in their order.
+	Subpartitions []Subpartition
+}
+
+// Subpartition is a single SUBPARTITION definition.
+type Subpartition struct {
+	Name    string
+	Options PartitionOptions
+}
+
+// PartitionOptions are the options of a partition or subpartition. All of them might be nil.
+type PartitionOptions struct {
+	Engine         *string
+	Comment        *string
+	DataDirectory  *string
+	IndexDirectory *string
+	MaxRows        *int
+	MinRows        *int
+	Tablespace     *string
+	NodeGroup      *string
+}
@@ -0,0 +1,135 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+)
+
+// Schema is the set of tables that results from replaying statements in order,
+// like the migration files of a database.
+type Schema struct {
+	// Tables are the tables that exist after all applied statements, in the order of their creation.
+	Tables []Table
+}
+
+// ApplyError signifies that a statement could not be applied to a Schema.
+type ApplyError struct {
+	// File is the name of the SQL file, that contains the statement. Might be empty.
+	File string
+	// Result is the position of the parse result in NewSchema, starting at 1. Zero, if the
+	// statement was applied by Schema.Apply.
+	Result int
+	// Statement is the statement that failed.
+	Statement Statement
+	// Err is the reason, like a DropErrorNotFound.
+	Err error
+}
+
+func (e ApplyError) Error() string {
+	position := fmt.Sprintf("line %d", e.Statement.Line)
+
+	switch {
+	case e.File != "":
+		position = fmt.Sprintf("%s:%d", e.File, e.Statement.Line)
+	case e.Result > 0:
+		position = fmt.Sprintf("result %d, line %d", e.Result, e.Statement.Line)
+	}
+
+	return fmt.Sprintf("%s: %s: %v", position, e.Statement, e.Err)
+}
+
+func (e ApplyError) Unwrap() error {
+	return e.Err
+}
+
+// ParsedFile is the parse result of an SQL file.
+type ParsedFile struct {
+	// Name is the name of the file, which is used to report errors.
+	Name   string
+	Result *ParseResult
+}
+
+// NewSchema replays the statements of the given parse results in order, like the
+// migration files 001 to N of a database. An ApplyError names the failing result by
+// its position, see NewSchemaFromFiles to name it by its file.
+func NewSchema(results ...*ParseResult) (*Schema, error) {
+	files := make([]ParsedFile, 0, len(results))
+	for _, result := range results {
+		files = append(files, ParsedFile{Result: result})
+	}
+
+	return NewSchemaFromFiles(files...)
+}
+
+// NewSchemaFromFiles replays the statements of the given files in order, like NewSchema.
+func NewSchemaFromFiles(files ...ParsedFile) (*Schema, error) {
+	schema := &Schema{}
+
+	for i, file := range files {
+		for _, statement := range file.Result.Statements {
+			if err := schema.applyStatement(statement); err != nil {
+				return nil, ApplyError{File: file.Name, Result: i + 1, Statement: statement, Err: err}
+			}
+		}
+	}
+
+	return schema, nil
+}
+
+// Apply applies all statements of the parse result in order. The file is only used to
+// report errors. Applying stops at the first statement that fails, so the tables
+// contain the changes of all statements before it.
+func (s *Schema) Apply(file string, result *ParseResult) error {
+	for _, statement := range result.Statements {
+		if err := s.applyStatement(statement); err != nil {
+			return ApplyError{File: file, Statement: statement, Err: err}
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) applyStatement(statement Statement) error {
+	switch {
+	case statement.CreateTable != nil:
+		table := statement.CreateTable.Clone()
+		if findTable(s.Tables, table.Schema, table.Name) >= 0 {
+			if table.IfNotExists {
+				return nil
+			}
+
+			return AddErrorExists{table.Name}
+		}
+
+		s.Tables = append(s.Tables, table)
+	case statement.Alter != nil:
+		index := findTable(s.Tables, statement.Alter.SchemaName(), statement.Alter.TableName())
+		if index < 0 {
+			return AlterErrorNotFound{statement.Alter.TableName()}
+		}
+
+		return statement.Alter.ApplyTo(&s.Tables[index])
+	case statement.Schema != nil:
+		tables, err := statement.Schema.ApplyToTables(s.Tables)
+		if err != nil {
+			return err
+		}
+
+		s.Tables = tables
+	}
+
+	return nil
+}
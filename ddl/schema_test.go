@@ -0,0 +1,112 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"errors"
+	"github.com/golangee/sql/ddl"
+	"github.com/golangee/sql/dialect/mysql"
+	"testing"
+)
+
+func parse(t *testing.T, sql string) *ddl.ParseResult {
+	t.Helper()
+
+	result, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func TestSchema_Apply(t *testing.T) {
+	schema, err := ddl.NewSchema(
+		parse(t, `
+CREATE TABLE Customer (Id INT PRIMARY KEY, Name VARCHAR(50));
+CREATE TABLE `+"`Order`"+` (Id INT, CustomerId INT, FOREIGN KEY (CustomerId) REFERENCES Customer (Id));
+`),
+		parse(t, `
+ALTER TABLE Customer ADD Email VARCHAR(100) AFTER Id, RENAME TO Client;
+ALTER TABLE `+"`Order`"+` DROP COLUMN Id;
+DROP TABLE IF EXISTS Cart;
+CREATE TABLE IF NOT EXISTS Client (Id INT);
+`),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(schema.Tables) != 2 {
+		t.Fatalf("Expected 2 tables, but got %d", len(schema.Tables))
+	}
+
+	client := schema.Tables[0]
+	if client.Name != "Client" || len(client.Columns) != 3 || client.Columns[1].Name != "Email" {
+		t.Fatalf("Failed to alter and rename the table, got %+v", client)
+	}
+
+	order := schema.Tables[1]
+	if len(order.Columns) != 1 || order.ForeignKeys[0].ReferenceTable != "Client" {
+		t.Fatalf("Failed to alter the table, got %+v", order)
+	}
+}
+
+func TestSchema_ApplyError(t *testing.T) {
+	schema, err := ddl.NewSchema(parse(t, "CREATE TABLE Client (Id INT);"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = schema.Apply("003_cleanup.sql", parse(t, "ALTER TABLE Client ADD Name TEXT;\nALTER TABLE Client\n  DROP COLUMN Email;"))
+
+	var applyError ddl.ApplyError
+	if !errors.As(err, &applyError) {
+		t.Fatalf("Expected an ApplyError, but got %v", err)
+	}
+
+	var notFound ddl.DropErrorNotFound
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected the cause to be a DropErrorNotFound, but got %v", applyError.Err)
+	}
+
+	expected := "003_cleanup.sql:2: ALTER TABLE Client: Email could not be dropped because it was not present"
+	if err.Error() != expected {
+		t.Fatalf("Expected error '%s', but got '%s'", expected, err)
+	}
+
+	// The statements before the failing one are applied.
+	if len(schema.Tables[0].Columns) != 2 {
+		t.Fatalf("Expected the first statement to be applied")
+	}
+
+	if _, err := ddl.NewSchema(parse(t, "CREATE TABLE A (Id INT);\nCREATE TABLE A (Id INT);")); err == nil {
+		t.Fatalf("Expected an error for a duplicate table")
+	}
+}
+
+func TestNewSchema_ApplyError(t *testing.T) {
+	first, second := parse(t, "CREATE TABLE A (Id INT);"), parse(t, "ALTER TABLE A ADD Name TEXT;\nDROP TABLE B;")
+
+	_, err := ddl.NewSchema(first, second)
+	if expected := "result 2, line 2: DROP TABLE B: B could not be dropped because it was not present"; err == nil || err.Error() != expected {
+		t.Fatalf("Expected error '%s', but got '%v'", expected, err)
+	}
+
+	_, err = ddl.NewSchemaFromFiles(ddl.ParsedFile{Name: "001_init.sql", Result: first}, ddl.ParsedFile{Name: "002_drop.sql", Result: second})
+	if expected := "002_drop.sql:2: DROP TABLE B: B could not be dropped because it was not present"; err == nil || err.Error() != expected {
+		t.Fatalf("Expected error '%s', but got '%v'", expected, err)
+	}
+}
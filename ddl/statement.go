@@ -0,0 +1,192 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+// Statement is a single CREATE TABLE, ALTER TABLE or schema statement at its position in the SQL.
+// Exactly one of CreateTable, Alter and Schema is set.
+type Statement struct {
+	// Line is the line in the SQL, where the statement starts.
+	Line int
+	// CreateTable is set for a CREATE TABLE statement.
+	CreateTable *Table
+	// Alter is set for a part of an ALTER TABLE, CREATE INDEX or DROP INDEX statement.
+	Alter AlterStatement
+	// Schema is set for a RENAME TABLE, DROP TABLE or TRUNCATE TABLE statement.
+	Schema SchemaStatement
+}
+
+// String describes the statement by its kind and its table, like ALTER TABLE shop.orders.
+func (s Statement) String() string {
+	switch {
+	case s.CreateTable != nil:
+		return "CREATE TABLE " + qualifiedName(s.CreateTable.Schema, s.CreateTable.Name)
+	case s.Alter != nil:
+		return "ALTER TABLE " + qualifiedName(s.Alter.SchemaName(), s.Alter.TableName())
+	case s.Schema != nil:
+		kind := "TRUNCATE TABLE "
+		switch s.Schema.(type) {
+		case RenameTable:
+			kind = "RENAME TABLE "
+		case DropTable:
+			kind = "DROP TABLE "
+		}
+
+		return kind + qualifiedName(s.Schema.SchemaName(), s.Schema.TableName())
+	default:
+		return "empty statement"
+	}
+}
+
+// qualifiedName joins a name with its database, like shop.orders, if the database is known.
+func qualifiedName(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+
+	return schema + "." + name
+}
+
+// SchemaStatement might be RENAME TABLE, DROP TABLE or TRUNCATE TABLE.
+// Unlike an AlterStatement, it is applied to all tables of a schema, because it
+// changes which tables exist or affects more than one of them.
+type SchemaStatement interface {
+	// SchemaName returns the database of the Table that this statement refers to.
+	SchemaName() string
+	// TableName returns the name of the Table that this statement refers to.
+	TableName() string
+	// ApplyToTables applies the statement to the given tables and returns the resulting tables.
+	// The given tables are not changed. Returns an error if applying failed.
+	ApplyToTables(tables []Table) ([]Table, error)
+}
+
+// RenameTable describes a RENAME TABLE 'old' TO 'new' or an ALTER TABLE 'old' RENAME TO 'new' statement.
+// A RENAME TABLE statement with multiple renames results in one RenameTable for each of them.
+type RenameTable struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
+	// Table is the current name of the table.
+	Table string
+	// NewSchema is the database that the table is moved to. It is the same as Schema,
+	// unless the table is moved to another database.
+	NewSchema string
+	// NewTable is the name of the table after the statement.
+	NewTable string
+}
+
+// DropTable describes a DROP TABLE [IF EXISTS] 'table' statement.
+// A DROP TABLE statement with multiple tables results in one DropTable for each of them.
+type DropTable struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
+	// Table is the name of the table to drop.
+	Table string
+	// IfExists is set, if a missing table should be ignored.
+	IfExists bool
+}
+
+// TruncateTable describes a TRUNCATE TABLE 'table' statement. It removes all rows, but
+// does not change the definition of the table.
+type TruncateTable struct {
+	// Schema is the database of Table. Empty, if it is unknown.
+	Schema string
+	// Table is the name of the table to truncate.
+	Table string
+}
+
+// findTable returns the index of the table with the given name, or -1 if there is none.
+func findTable(tables []Table, schema, name string) int {
+	for i, table := range tables {
+		if table.Schema == schema && table.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s RenameTable) SchemaName() string {
+	return s.Schema
+}
+
+func (s RenameTable) TableName() string {
+	return s.Table
+}
+
+func (s RenameTable) ApplyToTables(tables []Table) ([]Table, error) {
+	index := findTable(tables, s.Schema, s.Table)
+	if index < 0 {
+		return tables, AlterErrorNotFound{s.Table}
+	}
+
+	if findTable(tables, s.NewSchema, s.NewTable) >= 0 {
+		return tables, AddErrorExists{s.NewTable}
+	}
+
+	tables = cloneTables(tables)
+	tables[index].Schema = s.NewSchema
+	tables[index].Name = s.NewTable
+
+	// Foreign keys follow the renamed table, like in MySQL.
+	for i := range tables {
+		for j, foreignKey := range tables[i].ForeignKeys {
+			if foreignKey.ReferenceSchema == s.Schema && foreignKey.ReferenceTable == s.Table {
+				tables[i].ForeignKeys[j].ReferenceSchema = s.NewSchema
+				tables[i].ForeignKeys[j].ReferenceTable = s.NewTable
+			}
+		}
+	}
+
+	return tables, nil
+}
+
+func (s DropTable) SchemaName() string {
+	return s.Schema
+}
+
+func (s DropTable) TableName() string {
+	return s.Table
+}
+
+func (s DropTable) ApplyToTables(tables []Table) ([]Table, error) {
+	index := findTable(tables, s.Schema, s.Table)
+	if index < 0 {
+		if s.IfExists {
+			return tables, nil
+		}
+
+		return tables, DropErrorNotFound{s.Table}
+	}
+
+	result := make([]Table, 0, len(tables)-1)
+	result = append(result, tables[:index]...)
+
+	return append(result, tables[index+1:]...), nil
+}
+
+func (s TruncateTable) SchemaName() string {
+	return s.Schema
+}
+
+func (s TruncateTable) TableName() string {
+	return s.Table
+}
+
+func (s TruncateTable) ApplyToTables(tables []Table) ([]Table, error) {
+	if findTable(tables, s.Schema, s.Table) < 0 {
+		return tables, AlterErrorNotFound{s.Table}
+	}
+
+	return tables, nil
+}
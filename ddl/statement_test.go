@@ -0,0 +1,88 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"github.com/golangee/sql/ddl"
+	"testing"
+)
+
+func TestRenameTable_Apply(t *testing.T) {
+	tables := []ddl.Table{
+		{Schema: "shop", Name: "Customer"},
+		{Schema: "shop", Name: "Order", ForeignKeys: []ddl.ForeignKeyConstraint{
+			{Columns: []string{"CustomerId"}, ReferenceSchema: "shop", ReferenceTable: "Customer"},
+		}},
+		{Schema: "crm", Name: "Customer"},
+	}
+
+	rename := ddl.RenameTable{Schema: "shop", Table: "Customer", NewSchema: "crm", NewTable: "Client"}
+
+	tables, err := rename.ApplyToTables(tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tables[0].Schema != "crm" || tables[0].Name != "Client" {
+		t.Fatalf("Failed to rename table, got %s.%s", tables[0].Schema, tables[0].Name)
+	}
+
+	if foreignKey := tables[1].ForeignKeys[0]; foreignKey.ReferenceSchema != "crm" || foreignKey.ReferenceTable != "Client" {
+		t.Fatalf("Failed to update the foreign key, got %s.%s", foreignKey.ReferenceSchema, foreignKey.ReferenceTable)
+	}
+
+	if _, err := rename.ApplyToTables(tables); err == nil {
+		t.Fatalf("Expected an error for a missing table")
+	}
+
+	rename = ddl.RenameTable{Schema: "crm", Table: "Client", NewSchema: "crm", NewTable: "Customer"}
+	if _, err := rename.ApplyToTables(tables); err == nil {
+		t.Fatalf("Expected an error for an existing table")
+	}
+}
+
+func TestDropTable_Apply(t *testing.T) {
+	tables := []ddl.Table{{Name: "A"}, {Name: "B"}}
+
+	tables, err := ddl.DropTable{Table: "A"}.ApplyToTables(tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tables) != 1 || tables[0].Name != "B" {
+		t.Fatalf("Failed to drop table")
+	}
+
+	if _, err := (ddl.DropTable{Table: "A"}.ApplyToTables(tables)); err == nil {
+		t.Fatalf("Expected an error for a missing table")
+	}
+
+	if _, err := (ddl.DropTable{Table: "A", IfExists: true}.ApplyToTables(tables)); err != nil {
+		t.Fatalf("Expected no error for a missing table with IF EXISTS, got %v", err)
+	}
+}
+
+func TestTruncateTable_Apply(t *testing.T) {
+	tables := []ddl.Table{{Name: "A", Columns: []ddl.Column{{Name: "Id"}}}}
+
+	tables, err := ddl.TruncateTable{Table: "A"}.ApplyToTables(tables)
+	if err != nil || len(tables) != 1 || len(tables[0].Columns) != 1 {
+		t.Fatalf("Expected the table to stay unchanged, got %v", err)
+	}
+
+	if _, err := (ddl.TruncateTable{Table: "B"}.ApplyToTables(tables)); err == nil {
+		t.Fatalf("Expected an error for a missing table")
+	}
+}
@@ -17,6 +17,7 @@ package diagram
 import (
 	"fmt"
 	"github.com/golangee/sql/ddl"
+	"html"
 	"math/rand"
 	"os/exec"
 	"strconv"
@@ -38,6 +39,12 @@ const (
 // GenerateDot creates a graphviz representation of the relationships
 // between the given tables.
 func GenerateDot(tables []ddl.Table, layout LayoutAlgorithm) string {
+	return GenerateDotWithViews(tables, nil, layout)
+}
+
+// GenerateDotWithViews is like GenerateDot, but also draws the views
+// and the tables and views they read from.
+func GenerateDotWithViews(tables []ddl.Table, views []ddl.View, layout LayoutAlgorithm) string {
 	g := dot.NewGraph(dot.Undirected)
 	g.Attr("layout", layout)
 	g.Attr("overlap", "false") // can be "false", "scale", "true"
@@ -45,21 +52,58 @@ func GenerateDot(tables []ddl.Table, layout LayoutAlgorithm) string {
 	// Collect table nodes while drawing here
 	tableNodes := make(map[string]dot.Node)
 
+	// Names only need to be qualified, if the tables are spread over multiple schemas.
+	schemas := make(map[string]bool)
+	for _, table := range tables {
+		schemas[table.Schema] = true
+	}
+
+	label := func(schema, name string) string {
+		if len(schemas) > 1 {
+			return nodeID(schema, name)
+		}
+
+		return name
+	}
+
 	// Draw tables and their attributes
 	for _, table := range tables {
-		tableID := table.Name // A tables name is unique, so it can be used as an id
-		tableNode := g.Node(tableID).Label(table.Name).Box()
+		tableID := nodeID(table.Schema, table.Name)
+		tableNode := g.Node(tableID).Label(label(table.Schema, table.Name)).Box()
 		tableNodes[tableID] = tableNode
 
+		// The PRIMARY KEY may also be declared as a table level constraint.
+		primaryKeyColumns := make(map[string]bool)
+		if table.PrimaryKey != nil {
+			for _, part := range table.PrimaryKey.Parts {
+				primaryKeyColumns[part.Column] = true
+			}
+		}
+
 		for _, column := range table.Columns {
-			name := fmt.Sprintf("%s: %s", column.Name, column.Type)
+			lines := columnLabel(column)
 			columnNode := g.Node(randomNodeID())
 
-			// Underline PRIMARY KEY by using Literal
-			if column.PrimaryKey {
-				columnNode.Attr("label", dot.Literal("<<u>"+name+"</u>>"))
+			primaryKey := column.PrimaryKey || primaryKeyColumns[column.Name]
+
+			// Underline PRIMARY KEY and italicize generated columns by using Literal
+			if primaryKey || column.Generated != nil {
+				for i, line := range lines {
+					lines[i] = html.EscapeString(line)
+				}
+
+				label := strings.Join(lines, "<br/>")
+				if primaryKey {
+					label = "<u>" + label + "</u>"
+				}
+
+				if column.Generated != nil {
+					label = "<i>" + label + "</i>"
+				}
+
+				columnNode.Attr("label", dot.Literal("<"+label+">"))
 			} else {
-				columnNode.Label(name)
+				columnNode.Label(strings.Join(lines, "\n"))
 			}
 
 			tableNode.Edge(columnNode)
@@ -72,21 +116,87 @@ func GenerateDot(tables []ddl.Table, layout LayoutAlgorithm) string {
 			relNode := g.Node(randomNodeID())
 			relNode.Attr("shape", "diamond")
 
+			// This constraint might not have a name, use "ref" as a default
+			label := "ref"
 			if foreignKey.Name != nil {
-				relNode.Label(*foreignKey.Name)
-			} else {
-				// This constraint does not have a name, use "ref" as a default
-				relNode.Label("ref")
+				label = *foreignKey.Name
+			}
+
+			// Show which columns are connected, which is most useful for composite keys.
+			label += fmt.Sprintf("\n%s → %s",
+				strings.Join(foreignKey.Columns, ", "), strings.Join(foreignKey.ReferenceColumns, ", "))
+
+			// Cascading deletes and updates are important to know about, when looking at the schema.
+			if foreignKey.OnDelete != "" {
+				label += "\nON DELETE " + string(foreignKey.OnDelete)
+			}
+
+			if foreignKey.OnUpdate != "" {
+				label += "\nON UPDATE " + string(foreignKey.OnUpdate)
 			}
 
-			g.Edge(tableNodes[table.Name], relNode).Label("N")
-			g.Edge(relNode, tableNodes[foreignKey.ReferenceTable]).Label("1")
+			relNode.Label(label)
+
+			g.Edge(tableNodes[nodeID(table.Schema, table.Name)], relNode).Label("N")
+			g.Edge(relNode, tableNodes[nodeID(foreignKey.ReferenceSchema, foreignKey.ReferenceTable)]).Label("1")
+		}
+	}
+
+	// Draw views, which are connected by dashed edges to the tables they read from.
+	// Views may also read from other views, so create all nodes before the edges.
+	for _, view := range views {
+		viewID := nodeID(view.Schema, view.Name)
+		tableNodes[viewID] = g.Node(viewID).Label(label(view.Schema, view.Name)).Box().Attr("style", "rounded")
+	}
+
+	for _, view := range views {
+		for _, dependency := range view.Dependencies {
+			// Dependencies in the schema of the view are not qualified.
+			if !strings.Contains(dependency, ".") {
+				dependency = nodeID(view.Schema, dependency)
+			}
+
+			// Unknown dependencies are not part of the diagram.
+			if sourceNode, ok := tableNodes[dependency]; ok {
+				g.Edge(tableNodes[nodeID(view.Schema, view.Name)], sourceNode).Attr("style", "dashed")
+			}
 		}
 	}
 
 	return g.String()
 }
 
+// nodeID returns a unique id for a table or view, like shop.orders.
+func nodeID(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+
+	return schema + "." + name
+}
+
+// columnLabel returns the lines that describe a column in the diagram.
+func columnLabel(column ddl.Column) []string {
+	lines := []string{fmt.Sprintf("%s: %s", column.Name, column.Type)}
+
+	// ENUM and SET columns list their allowed values in a line of their own,
+	// because the values make the type too long to read.
+	if column.Type.IsCollection() {
+		lines = []string{
+			fmt.Sprintf("%s: %s", column.Name, column.Type.Name),
+			"{" + strings.Join(column.Type.Values, " | ") + "}",
+		}
+	}
+
+	// Show how generated columns are computed.
+	if column.Generated != nil {
+		lines = append(lines, fmt.Sprintf("= %s (%s)",
+			strings.TrimSpace(column.Generated.Expression), column.Generated.Storage))
+	}
+
+	return lines
+}
+
 // DotToSvg converts dot representation of a graph to an svg image
 // using the "dot" executable.
 func DotToSvg(dot string) (string, error) {
@@ -0,0 +1,119 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagram_test
+
+import (
+	"github.com/golangee/sql/diagram"
+	"github.com/golangee/sql/dialect/mysql"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var (
+	dotNode = regexp.MustCompile(`^\s*(n\d+)\[label=(".*?"|<.*>)[,\]]`)
+	dotEdge = regexp.MustCompile(`^\s*(n\d+)--(n\d+)(\[.*\])?;$`)
+)
+
+// generateShop returns the node labels and the edges of the diagram of testdata/shop.sql. Node ids are
+// random, so edges refer to their nodes by label, like "ActiveCustomer" -- "Customer" [style="dashed"].
+func generateShop(t *testing.T) (labels map[string]bool, edges map[string]bool) {
+	t.Helper()
+
+	sql, err := ioutil.ReadFile("testdata/shop.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := mysql.Parse(string(sql))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(diagram.GenerateDotWithViews(result.Tables, result.Views, diagram.Dot), "\n")
+
+	nodes := make(map[string]string)
+	labels = make(map[string]bool)
+
+	for _, line := range lines {
+		if match := dotNode.FindStringSubmatch(line); match != nil {
+			nodes[match[1]] = match[2]
+			labels[match[2]] = true
+		}
+	}
+
+	edges = make(map[string]bool)
+
+	for _, line := range lines {
+		if match := dotEdge.FindStringSubmatch(line); match != nil {
+			edges[nodes[match[1]]+" -- "+nodes[match[2]]+" "+match[3]] = true
+		}
+	}
+
+	return labels, edges
+}
+
+func TestGenerateDot_Labels(t *testing.T) {
+	labels, _ := generateShop(t)
+
+	for _, expected := range []string{
+		`"Customer"`,
+		`<<u>Id: INT</u>>`,
+		`"FirstName: VARCHAR(100)"`,
+		// ENUM values are listed in a line of their own.
+		`"Status: ENUM\n{new | active | blocked}"`,
+		// Generated columns are italic and show their expression.
+		`<<i>FullName: VARCHAR(201)<br/>= CONCAT(FirstName, &#39; &#39;, LastName) (VIRTUAL)</i>>`,
+		`"OrderCustomer\nCustomerId → Id\nON DELETE CASCADE"`,
+		`"ActiveCustomer"`,
+	} {
+		if !labels[expected] {
+			t.Errorf("Expected label %s in %v", expected, labels)
+		}
+	}
+}
+
+func TestGenerateDot_Edges(t *testing.T) {
+	_, edges := generateShop(t)
+
+	for _, expected := range []string{
+		`"Customer" -- "Status: ENUM\n{new | active | blocked}" `,
+		`"Order" -- "OrderCustomer\nCustomerId → Id\nON DELETE CASCADE" [label="N"]`,
+		`"OrderCustomer\nCustomerId → Id\nON DELETE CASCADE" -- "Customer" [label="1"]`,
+		// Views are connected to the tables they read from by dashed edges.
+		`"ActiveCustomer" -- "Customer" [style="dashed"]`,
+	} {
+		if !edges[expected] {
+			t.Errorf("Expected edge %s in %v", expected, edges)
+		}
+	}
+}
+
+func TestGenerateDot_WithoutViews(t *testing.T) {
+	sql, err := ioutil.ReadFile("testdata/shop.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := mysql.Parse(string(sql))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dot := diagram.GenerateDot(result.Tables, diagram.Dot); strings.Contains(dot, "ActiveCustomer") {
+		t.Fatalf("Expected no views in %s", dot)
+	}
+}
@@ -0,0 +1,16 @@
+CREATE TABLE Customer (
+  Id INT NOT NULL PRIMARY KEY,
+  Status ENUM('new', 'active', 'blocked') NOT NULL,
+  FirstName VARCHAR(100),
+  LastName VARCHAR(100),
+  FullName VARCHAR(201) AS (CONCAT(FirstName, ' ', LastName)) VIRTUAL
+);
+
+CREATE TABLE `Order` (
+  Id INT NOT NULL,
+  CustomerId INT NOT NULL,
+  PRIMARY KEY (Id),
+  CONSTRAINT OrderCustomer FOREIGN KEY (CustomerId) REFERENCES Customer (Id) ON DELETE CASCADE
+);
+
+CREATE VIEW ActiveCustomer AS SELECT Id, FullName FROM Customer WHERE Status = 'active';
@@ -0,0 +1,200 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+	"github.com/golangee/sql/dialect/mysql/parser"
+	"strings"
+)
+
+// attributeChannel carries the tokens of attributes, that MySQL 8 accepts, but the grammar does not:
+// [NOT] ENFORCED after a CHECK constraint and VISIBLE or INVISIBLE in the definition of a column.
+// The parser skips them, and the listener reads them from the token stream.
+const attributeChannel = parser.MySqlLexerERRORCHANNEL + 1
+
+// attributeLexer moves the attributes, that the grammar does not accept, to the attributeChannel.
+type attributeLexer struct {
+	*parser.MySqlLexer
+	tokens []antlr.Token
+}
+
+// newAttributeLexer reads all tokens of the lexer, so that errors are reported to its listeners.
+func newAttributeLexer(lexer *parser.MySqlLexer) *attributeLexer {
+	return &attributeLexer{MySqlLexer: lexer, tokens: hideAttributes(lexer.GetAllTokens())}
+}
+
+func (l *attributeLexer) NextToken() antlr.Token {
+	if len(l.tokens) == 0 {
+		return l.MySqlLexer.NextToken()
+	}
+
+	token := l.tokens[0]
+	l.tokens = l.tokens[1:]
+
+	return token
+}
+
+// hideAttributes returns the tokens, in which the attributes are moved to the attributeChannel.
+func hideAttributes(tokens []antlr.Token) []antlr.Token {
+	var visible []int
+
+	for i, token := range tokens {
+		if token.GetChannel() == antlr.TokenDefaultChannel {
+			visible = append(visible, i)
+		}
+	}
+
+	tokenType := func(k int) int {
+		if k < 0 || k >= len(visible) {
+			return antlr.TokenEOF
+		}
+
+		return tokens[visible[k]].GetTokenType()
+	}
+
+	// ENFORCED is no keyword of the grammar.
+	isEnforced := func(k int) bool {
+		return tokenType(k) == parser.MySqlLexerID && strings.EqualFold(tokens[visible[k]].GetText(), "ENFORCED")
+	}
+
+	hide := func(k int) {
+		token := tokens[visible[k]]
+		tokens[visible[k]] = antlr.CommonTokenFactoryDEFAULT.Create(token.GetSource(), token.GetTokenType(),
+			token.GetText(), attributeChannel, token.GetStart(), token.GetStop(), token.GetLine(), token.GetColumn())
+	}
+
+	// parens is set for each open parenthesis, that encloses the expression of a CHECK constraint.
+	var parens []bool
+
+	// statement is the first token of the current statement, and table is set after its TABLE keyword.
+	statement, table := antlr.TokenEOF, false
+
+	// definitions is the depth of the definitions of a CREATE TABLE, or 0 outside of them.
+	definitions, definitionsDone := 0, false
+
+	// column is the depth of the definition of a column, and attributes is the position of its first
+	// attribute. column is -1 outside of a column.
+	column, attributes := -1, 0
+
+	// startDefinition begins the definition at position k of a CREATE TABLE, if it defines a column.
+	startDefinition := func(k int) {
+		switch tokenType(k) {
+		case parser.MySqlLexerINDEX, parser.MySqlLexerKEY, parser.MySqlLexerUNIQUE, parser.MySqlLexerPRIMARY,
+			parser.MySqlLexerFULLTEXT, parser.MySqlLexerSPATIAL, parser.MySqlLexerCONSTRAINT,
+			parser.MySqlLexerFOREIGN, parser.MySqlLexerCHECK, parser.MySqlLexerLIKE:
+		default:
+			column, attributes = definitions, k+2
+		}
+	}
+
+	// startAlteration begins the ADD, MODIFY or CHANGE at position k of an ALTER TABLE, if it defines a column.
+	startAlteration := func(k int) {
+		name := k + 1
+		if tokenType(name) == parser.MySqlLexerCOLUMN {
+			name++
+		}
+
+		switch tokenType(k) {
+		case parser.MySqlLexerADD:
+			switch tokenType(name) {
+			case parser.MySqlLexerINDEX, parser.MySqlLexerKEY, parser.MySqlLexerUNIQUE, parser.MySqlLexerPRIMARY,
+				parser.MySqlLexerFULLTEXT, parser.MySqlLexerSPATIAL, parser.MySqlLexerCONSTRAINT,
+				parser.MySqlLexerFOREIGN, parser.MySqlLexerCHECK, parser.MySqlLexerPARTITION,
+				parser.MySqlLexerLR_BRACKET:
+			default:
+				column, attributes = 0, name+2
+			}
+		case parser.MySqlLexerMODIFY:
+			column, attributes = 0, name+2
+		case parser.MySqlLexerCHANGE:
+			// CHANGE names the old and the new column.
+			column, attributes = 0, name+3
+		}
+	}
+
+	for k := range visible {
+		if statement == antlr.TokenEOF {
+			statement = tokenType(k)
+		}
+
+		switch tokenType(k) {
+		case parser.MySqlLexerSEMI:
+			parens = nil
+			statement, table = antlr.TokenEOF, false
+			definitions, definitionsDone = 0, false
+			column = -1
+		case parser.MySqlLexerTABLE:
+			if len(parens) == 0 {
+				table = true
+			}
+		case parser.MySqlLexerADD, parser.MySqlLexerMODIFY, parser.MySqlLexerCHANGE:
+			// A column may be named like the keywords, that follow ADD.
+			switch tokenType(k - 1) {
+			case parser.MySqlLexerADD, parser.MySqlLexerMODIFY, parser.MySqlLexerCHANGE, parser.MySqlLexerCOLUMN:
+				continue
+			}
+
+			if statement == parser.MySqlLexerALTER && table && len(parens) == 0 {
+				startAlteration(k)
+			}
+		case parser.MySqlLexerCOMMA:
+			if column == len(parens) {
+				column = -1
+			}
+
+			if definitions > 0 && definitions == len(parens) {
+				startDefinition(k + 1)
+			}
+		case parser.MySqlLexerVISIBLE, parser.MySqlLexerINVISIBLE:
+			if column == len(parens) && k >= attributes {
+				hide(k)
+			}
+		case parser.MySqlLexerLR_BRACKET:
+			parens = append(parens, tokenType(k-1) == parser.MySqlLexerCHECK)
+
+			if statement == parser.MySqlLexerCREATE && table && len(parens) == 1 && !definitionsDone {
+				definitions = 1
+				startDefinition(k + 1)
+			}
+		case parser.MySqlLexerRR_BRACKET:
+			if len(parens) == 0 {
+				continue
+			}
+
+			if column == len(parens) {
+				column = -1
+			}
+
+			if definitions == len(parens) {
+				definitions, definitionsDone = 0, true
+			}
+
+			check := parens[len(parens)-1]
+			parens = parens[:len(parens)-1]
+
+			switch {
+			case !check:
+			case isEnforced(k + 1):
+				hide(k + 1)
+			case tokenType(k+1) == parser.MySqlLexerNOT && isEnforced(k+2):
+				hide(k + 1)
+				hide(k + 2)
+			}
+		}
+	}
+
+	return tokens
+}
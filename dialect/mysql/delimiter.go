@@ -0,0 +1,128 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"strings"
+)
+
+// replaceDelimiters handles the DELIMITER command of the mysql client, which is not part of the
+// grammar. The commands are removed and every custom delimiter is replaced by a semicolon,
+// which the grammar can tell apart from the semicolons inside of BEGIN ... END blocks.
+// Delimiters in strings and comments are left alone. Line breaks are kept, so that
+// line numbers in errors still match the original SQL.
+func replaceDelimiters(sql string) string {
+	result := strings.Builder{}
+	delimiter := ";"
+	lineStart := true
+
+	for i := 0; i < len(sql); {
+		if lineStart {
+			lineStart = false
+
+			if next, ok := delimiterCommand(sql[i:]); ok {
+				// Drop the command, but not the line break after it.
+				end := strings.IndexByte(sql[i:], '\n')
+				if end < 0 {
+					end = len(sql) - i
+				}
+
+				delimiter = next
+				i += end
+
+				continue
+			}
+		}
+
+		c := sql[i]
+
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := closingQuote(sql, i)
+			result.WriteString(sql[i:end])
+			i = end
+
+			continue
+		case strings.HasPrefix(sql[i:], "-- ") || c == '#':
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = len(sql) - i
+			}
+
+			result.WriteString(sql[i : i+end])
+			i += end
+
+			continue
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				end = len(sql) - i
+			} else {
+				end += 4
+			}
+
+			result.WriteString(sql[i : i+end])
+			i += end
+
+			continue
+		case delimiter != ";" && strings.HasPrefix(sql[i:], delimiter):
+			result.WriteByte(';')
+			i += len(delimiter)
+
+			continue
+		}
+
+		lineStart = c == '\n'
+
+		result.WriteByte(c)
+		i++
+	}
+
+	return result.String()
+}
+
+// delimiterCommand returns the new delimiter, if the line starts with a DELIMITER command.
+func delimiterCommand(line string) (string, bool) {
+	if end := strings.IndexByte(line, '\n'); end >= 0 {
+		line = line[:end]
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "DELIMITER") {
+		return "", false
+	}
+
+	return fields[1], true
+}
+
+// closingQuote returns the index after the string or quoted name that starts at sql[start].
+// Doubled quotes are just two strings in a row here, which has the same effect.
+func closingQuote(sql string, start int) int {
+	quote := sql[start]
+
+	for i := start + 1; i < len(sql); i++ {
+		switch sql[i] {
+		case '\\':
+			// Backslash escapes are not supported in quoted names.
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			return i + 1
+		}
+	}
+
+	return len(sql)
+}
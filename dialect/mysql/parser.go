@@ -18,24 +18,26 @@ import (
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"github.com/golangee/sql/ddl"
 	"github.com/golangee/sql/dialect/mysql/parser"
+	"strconv"
 	"strings"
 )
 
 // Parse extracts all tables from CREATE TABLE statements from a given set of SQL statements.
 func Parse(sql string) (*ddl.ParseResult, error) {
-	input := antlr.NewInputStream(sql)
+	input := upperCaseStream{antlr.NewInputStream(replaceDelimiters(sql))}
 	lexer := parser.NewMySqlLexer(input)
-	stream := antlr.NewCommonTokenStream(lexer, 0)
-	parser := parser.NewMySqlParser(stream)
+	errorCollector := &errorCollector{}
 
-	parser.RemoveErrorListeners()
 	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(errorCollector)
 
-	errorCollector := &errorCollector{}
+	stream := antlr.NewCommonTokenStream(newAttributeLexer(lexer), 0)
+	parser := parser.NewMySqlParser(stream)
+
+	parser.RemoveErrorListeners()
 	parser.AddErrorListener(errorCollector)
-	lexer.AddErrorListener(errorCollector)
 
-	listener := newListener()
+	listener := newListener(stream)
 	antlr.ParseTreeWalkerDefault.Walk(listener, parser.Root())
 
 	if len(errorCollector.errors.messages) > 0 {
@@ -43,8 +45,14 @@ func Parse(sql string) (*ddl.ParseResult, error) {
 	}
 
 	return &ddl.ParseResult{
-		Tables:          listener.Tables,
-		AlterStatements: listener.AlterStatements,
+		Databases:        listener.Databases,
+		Tables:           listener.Tables,
+		AlterStatements:  listener.AlterStatements,
+		SchemaStatements: listener.SchemaStatements,
+		Statements:       listener.Statements,
+		Views:            listener.Views,
+		Triggers:         listener.Triggers,
+		Routines:         listener.Routines,
 	}, nil
 }
 
@@ -69,6 +77,12 @@ func (c *errorCollector) SyntaxError(recognizer antlr.Recognizer, offendingSymbo
 
 type listener struct {
 	*parser.BaseMySqlParserListener
+	// The tokens of the parsed SQL, which include the attributes that the grammar does not accept
+	Tokens *antlr.CommonTokenStream
+	// The current database, as selected by the last USE statement
+	Database string
+	// A list of all parsed CREATE DATABASE statements
+	Databases []ddl.Database
 	// The table that is currently being parsed
 	BuildingTable *ddl.Table
 	// The column that is currently being parsed
@@ -79,10 +93,56 @@ type listener struct {
 	Tables []ddl.Table
 	// A list of parsed ALTER TABLE statements
 	AlterStatements []ddl.AlterStatement
+	// A list of parsed RENAME TABLE, DROP TABLE and TRUNCATE TABLE statements
+	SchemaStatements []ddl.SchemaStatement
+	// The RENAME TO of the current ALTER TABLE statement, which is applied after its other parts
+	PendingRename *ddl.RenameTable
+	// All of the above CREATE TABLE, ALTER TABLE and schema statements in the order of the SQL
+	Statements []ddl.Statement
+	// The line where the current statement starts
+	Line int
+	// The view that is currently being parsed
+	BuildingView *ddl.View
+	// A list of all parsed CREATE VIEW statements
+	Views []ddl.View
+	// A list of all parsed CREATE TRIGGER statements
+	Triggers []ddl.Trigger
+	// A list of all parsed CREATE PROCEDURE and CREATE FUNCTION statements
+	Routines []ddl.Routine
+	// The number of CREATE PROCEDURE, CREATE FUNCTION and CREATE TRIGGER statements, whose body is parsed.
+	// Statements in a body only run when the routine is called, so they do not change the schema.
+	RoutineDepth int
 }
 
-func newListener() *listener {
-	return &listener{}
+func newListener(tokens *antlr.CommonTokenStream) *listener {
+	return &listener{Tokens: tokens}
+}
+
+// attributesAfter returns the tokens of the attributeChannel, that directly follow a rule.
+func (l *listener) attributesAfter(ctx antlr.ParserRuleContext) []antlr.Token {
+	// The rule has no tokens, if it could not be parsed.
+	if ctx.GetStop() == nil || ctx.GetStop().GetTokenIndex() < 0 {
+		return nil
+	}
+
+	return l.Tokens.GetHiddenTokensToRight(ctx.GetStop().GetTokenIndex(), attributeChannel)
+}
+
+// attributesOf returns the tokens of the attributeChannel within a rule and directly after it.
+func (l *listener) attributesOf(ctx antlr.ParserRuleContext) []antlr.Token {
+	if ctx.GetStop() == nil || ctx.GetStop().GetTokenIndex() < 0 {
+		return nil
+	}
+
+	var result []antlr.Token
+
+	for i := ctx.GetStart().GetTokenIndex(); i < ctx.GetStop().GetTokenIndex(); i++ {
+		if token := l.Tokens.Get(i); token.GetChannel() == attributeChannel {
+			result = append(result, token)
+		}
+	}
+
+	return append(result, l.attributesAfter(ctx)...)
 }
 
 // Trim SQL names for columns and tables by stripping quotes ("') and backticks (`).
@@ -90,20 +150,441 @@ func trimName(name string) string {
 	return strings.Trim(name, "`'\"")
 }
 
+// splitName splits a name like shop.`orders` into its schema and its name.
+// The schema is empty, if the name is not qualified.
+func splitName(ctx parser.IFullIdContext) (string, string) {
+	fullID := ctx.(*parser.FullIdContext)
+	uids := fullID.AllUid()
+
+	// The lexer reads .orders as a single token, if there is no quote or space after the dot.
+	if fullID.DOT_ID() != nil {
+		return trimName(uids[0].GetText()), trimName(fullID.DOT_ID().GetText()[1:])
+	}
+
+	if len(uids) == 2 {
+		return trimName(uids[0].GetText()), trimName(uids[1].GetText())
+	}
+
+	return "", trimName(uids[0].GetText())
+}
+
+// qualifiedName is like splitName, but defaults to the current database for unqualified names.
+func (l *listener) qualifiedName(ctx parser.IFullIdContext) (string, string) {
+	schema, name := splitName(ctx)
+	if schema == "" {
+		schema = l.Database
+	}
+
+	return schema, name
+}
+
+// tableName is like qualifiedName for the tableName rule.
+func (l *listener) tableName(ctx parser.ITableNameContext) (string, string) {
+	return l.qualifiedName(ctx.(*parser.TableNameContext).FullId())
+}
+
+// addAlterStatement saves an ALTER statement, which is a part of the current statement.
+func (l *listener) addAlterStatement(statement ddl.AlterStatement) {
+	if l.RoutineDepth > 0 {
+		return
+	}
+
+	l.AlterStatements = append(l.AlterStatements, statement)
+	l.Statements = append(l.Statements, ddl.Statement{Line: l.Line, Alter: statement})
+}
+
+// addSchemaStatement saves a RENAME TABLE, DROP TABLE or TRUNCATE TABLE statement.
+func (l *listener) addSchemaStatement(statement ddl.SchemaStatement) {
+	if l.RoutineDepth > 0 {
+		return
+	}
+
+	l.SchemaStatements = append(l.SchemaStatements, statement)
+	l.Statements = append(l.Statements, ddl.Statement{Line: l.Line, Schema: statement})
+}
+
+// Remember where a statement starts, so that it can be found in the SQL.
+func (l *listener) EnterSqlStatement(ctx *parser.SqlStatementContext) {
+	if l.RoutineDepth == 0 {
+		l.Line = ctx.GetStart().GetLine()
+	}
+}
+
+// --- Callbacks for databases
+
+// A USE statement selects the database for all following unqualified names.
+func (l *listener) EnterUseStatement(ctx *parser.UseStatementContext) {
+	l.Database = trimName(ctx.Uid().GetText())
+}
+
+// A CREATE DATABASE or CREATE SCHEMA statement was detected.
+func (l *listener) EnterCreateDatabase(ctx *parser.CreateDatabaseContext) {
+	database := ddl.Database{
+		Name:        trimName(ctx.Uid().GetText()),
+		IfNotExists: ctx.IfNotExists() != nil,
+	}
+
+	for _, option := range ctx.AllCreateDatabaseOption() {
+		option := option.(*parser.CreateDatabaseOptionContext)
+
+		switch {
+		case option.CollationName() != nil:
+			collation := trimName(option.CollationName().GetText())
+			database.Collate = &collation
+		case option.CharsetName() != nil:
+			charset := trimName(option.CharsetName().GetText())
+			database.CharacterSet = &charset
+		default:
+			// Like in CHARACTER SET = DEFAULT
+			charset := "DEFAULT"
+			database.CharacterSet = &charset
+		}
+	}
+
+	l.Databases = append(l.Databases, database)
+}
+
+// indexParts returns all columns in a list like (a, `b`, c(10) DESC).
+// The list is optional in some places of the grammar, so ctx may be nil.
+func indexParts(ctx parser.IIndexColumnNamesContext) []ddl.IndexPart {
+	if ctx == nil {
+		return nil
+	}
+
+	var parts []ddl.IndexPart
+
+	for _, col := range ctx.(*parser.IndexColumnNamesContext).AllIndexColumnName() {
+		col := col.(*parser.IndexColumnNameContext)
+		part := ddl.IndexPart{}
+
+		if col.Uid() != nil {
+			part.Column = trimName(col.Uid().GetText())
+		} else {
+			part.Column = trimName(col.STRING_LITERAL().GetText())
+		}
+
+		if col.DecimalLiteral() != nil {
+			// The grammar only allows plain decimal literals here.
+			part.Length, _ = strconv.Atoi(col.DecimalLiteral().GetText())
+		}
+
+		part.Descending = col.DESC() != nil
+		parts = append(parts, part)
+	}
+
+	return parts
+}
+
+// indexColumnNames returns only the names of all columns in a list like (a, `b`, c).
+func indexColumnNames(ctx parser.IIndexColumnNamesContext) []string {
+	var names []string
+	for _, part := range indexParts(ctx) {
+		names = append(names, part.Column)
+	}
+
+	return names
+}
+
+// sourceText returns the SQL of a rule as it was written, including whitespace.
+// GetText in contrast concatenates all tokens without any separator.
+func sourceText(ctx antlr.ParserRuleContext) string {
+	start, stop := ctx.GetStart(), ctx.GetStop()
+
+	return start.GetInputStream().GetText(start.GetStart(), stop.GetStop())
+}
+
+// unquoteString returns the value of an SQL string literal like 'it''s' or "a\tb".
+func unquoteString(literal string) string {
+	if len(literal) < 2 {
+		return literal
+	}
+
+	quote := literal[0]
+	inner := literal[1 : len(literal)-1]
+	result := strings.Builder{}
+
+	for i := 0; i < len(inner); i++ {
+		c := inner[i]
+
+		switch {
+		case c == quote && i+1 < len(inner) && inner[i+1] == quote:
+			// A doubled quote is an escaped quote.
+			result.WriteByte(quote)
+			i++
+		case c == '\\' && i+1 < len(inner):
+			i++
+			switch inner[i] {
+			case '0':
+				result.WriteByte(0)
+			case 'b':
+				result.WriteByte('\b')
+			case 'n':
+				result.WriteByte('\n')
+			case 'r':
+				result.WriteByte('\r')
+			case 't':
+				result.WriteByte('\t')
+			case 'Z':
+				result.WriteByte(26)
+			case '%', '_':
+				// These are only escaped in LIKE patterns, MySQL keeps the backslash.
+				result.WriteByte('\\')
+				result.WriteByte(inner[i])
+			default:
+				result.WriteByte(inner[i])
+			}
+		default:
+			result.WriteByte(c)
+		}
+	}
+
+	return result.String()
+}
+
 // A new CREATE TABLE statement was detected.
 func (l *listener) EnterColumnCreateTable(ctx *parser.ColumnCreateTableContext) {
-	name := ctx.TableName().GetText()
-	name = trimName(name)
+	schema, name := l.tableName(ctx.TableName())
 	l.BuildingTable = &ddl.Table{
+		Schema:      schema,
 		Name:        name,
 		IfNotExists: ctx.IfNotExists() != nil,
+		Options:     tableOptions(ctx.AllTableOption()),
+	}
+
+	if ctx.PartitionDefinitions() != nil {
+		l.BuildingTable.Partitioning = partitioning(ctx.PartitionDefinitions().(*parser.PartitionDefinitionsContext))
 	}
 }
 
+// tableOptions collects the options that follow the definitions of a CREATE TABLE statement.
+func tableOptions(options []parser.ITableOptionContext) ddl.TableOptions {
+	result := ddl.TableOptions{}
+
+	for _, option := range options {
+		switch option := option.(type) {
+		case *parser.TableOptionEngineContext:
+			engine := trimName(option.EngineName().GetText())
+			result.Engine = &engine
+		case *parser.TableOptionAutoIncrementContext:
+			autoIncrement := option.DecimalLiteral().GetText()
+			result.AutoIncrement = &autoIncrement
+		case *parser.TableOptionCharsetContext:
+			// The character set may be DEFAULT itself, like in CHARSET=DEFAULT.
+			charset := "DEFAULT"
+			if option.CharsetName() != nil {
+				charset = trimName(option.CharsetName().GetText())
+			}
+
+			result.CharacterSet = &charset
+		case *parser.TableOptionCollateContext:
+			collation := trimName(option.CollationName().GetText())
+			result.Collate = &collation
+		case *parser.TableOptionCommentContext:
+			comment := unquoteString(option.STRING_LITERAL().GetText())
+			result.Comment = &comment
+		case *parser.TableOptionRowFormatContext:
+			rowFormat := strings.ToUpper(option.GetRowFormat().GetText())
+			result.RowFormat = &rowFormat
+		default:
+			result.Others = append(result.Others, sourceText(option))
+		}
+	}
+
+	return result
+}
+
+// partitioning converts the PARTITION BY clause of a CREATE TABLE statement.
+func partitioning(ctx *parser.PartitionDefinitionsContext) *ddl.Partitioning {
+	result := &ddl.Partitioning{
+		Function:          partitionFunction(ctx.PartitionFunctionDefinition()),
+		Count:             decimal(ctx.GetCount()),
+		SubpartitionCount: decimal(ctx.GetSubCount()),
+	}
+
+	if ctx.SubpartitionFunctionDefinition() != nil {
+		subpartitioning := partitionFunction(ctx.SubpartitionFunctionDefinition())
+		result.Subpartitioning = &subpartitioning
+	}
+
+	for _, definition := range ctx.AllPartitionDefinition() {
+		result.Partitions = append(result.Partitions, partition(definition))
+	}
+
+	return result
+}
+
+// partitionFunction converts both PARTITION BY and SUBPARTITION BY functions,
+// which only differ in the allowed methods.
+func partitionFunction(ctx antlr.ParserRuleContext) ddl.PartitionFunction {
+	result := ddl.PartitionFunction{}
+
+	switch ctx := ctx.(type) {
+	case *parser.PartitionFunctionHashContext:
+		result.Method = ddl.PartitionHash
+		result.Linear = ctx.LINEAR() != nil
+		result.Expression = sourceText(ctx.Expression())
+	case *parser.SubPartitionFunctionHashContext:
+		result.Method = ddl.PartitionHash
+		result.Linear = ctx.LINEAR() != nil
+		result.Expression = sourceText(ctx.Expression())
+	case *parser.PartitionFunctionKeyContext:
+		result.Method = ddl.PartitionKey
+		result.Linear = ctx.LINEAR() != nil
+		result.Columns = uidList(ctx.UidList())
+		result.Algorithm = keyAlgorithm(ctx.GetAlgType())
+	case *parser.SubPartitionFunctionKeyContext:
+		result.Method = ddl.PartitionKey
+		result.Linear = ctx.LINEAR() != nil
+		result.Columns = uidList(ctx.UidList())
+		result.Algorithm = keyAlgorithm(ctx.GetAlgType())
+	case *parser.PartitionFunctionRangeContext:
+		result.Method = ddl.PartitionRange
+		if ctx.Expression() != nil {
+			result.Expression = sourceText(ctx.Expression())
+		}
+
+		result.Columns = uidList(ctx.UidList())
+	case *parser.PartitionFunctionListContext:
+		result.Method = ddl.PartitionList
+		if ctx.Expression() != nil {
+			result.Expression = sourceText(ctx.Expression())
+		}
+
+		result.Columns = uidList(ctx.UidList())
+	}
+
+	return result
+}
+
+// keyAlgorithm returns the optional ALGORITHM of KEY partitioning.
+func keyAlgorithm(token antlr.Token) *int {
+	if token == nil {
+		return nil
+	}
+
+	// The grammar only allows 1 and 2 here.
+	algorithm, _ := strconv.Atoi(token.GetText())
+
+	return &algorithm
+}
+
+// uidList returns the names in a list like (a, `b`), which may be nil.
+func uidList(ctx parser.IUidListContext) []string {
+	if ctx == nil {
+		return nil
+	}
+
+	var names []string
+	for _, uid := range ctx.(*parser.UidListContext).AllUid() {
+		names = append(names, trimName(uid.GetText()))
+	}
+
+	return names
+}
+
+// partitionDefinition is implemented by all alternatives of the partitionDefinition rule.
+type partitionDefinition interface {
+	Uid() parser.IUidContext
+	AllPartitionOption() []parser.IPartitionOptionContext
+	AllSubpartitionDefinition() []parser.ISubpartitionDefinitionContext
+}
+
+// partition converts a single PARTITION definition.
+// The grammar does not expect the parentheses that MySQL requires around a list of subpartitions,
+// so explicit subpartitions can only be parsed when written like PARTITION p0 SUBPARTITION s0, SUBPARTITION s1.
+func partition(ctx parser.IPartitionDefinitionContext) ddl.Partition {
+	definition := ctx.(partitionDefinition)
+	result := ddl.Partition{
+		Name:    trimName(definition.Uid().GetText()),
+		Options: partitionOptions(definition.AllPartitionOption()),
+	}
+
+	switch ctx := ctx.(type) {
+	case *parser.PartitionComparisionContext:
+		for _, atom := range ctx.AllPartitionDefinerAtom() {
+			result.LessThan = append(result.LessThan, partitionValue(atom))
+		}
+	case *parser.PartitionListAtomContext:
+		for _, atom := range ctx.AllPartitionDefinerAtom() {
+			result.In = append(result.In, partitionValue(atom))
+		}
+	case *parser.PartitionListVectorContext:
+		for _, vector := range ctx.AllPartitionDefinerVector() {
+			var values []string
+			for _, atom := range vector.(*parser.PartitionDefinerVectorContext).AllPartitionDefinerAtom() {
+				values = append(values, partitionValue(atom))
+			}
+
+			result.In = append(result.In, "("+strings.Join(values, ", ")+")")
+		}
+	}
+
+	for _, sub := range definition.AllSubpartitionDefinition() {
+		sub := sub.(*parser.SubpartitionDefinitionContext)
+		result.Subpartitions = append(result.Subpartitions, ddl.Subpartition{
+			Name:    trimName(sub.Uid().GetText()),
+			Options: partitionOptions(sub.AllPartitionOption()),
+		})
+	}
+
+	return result
+}
+
+// partitionValue returns a bound or list value of a partition as written in the SQL.
+func partitionValue(ctx parser.IPartitionDefinerAtomContext) string {
+	atom := ctx.(*parser.PartitionDefinerAtomContext)
+	if atom.MAXVALUE() != nil {
+		return "MAXVALUE"
+	}
+
+	return sourceText(atom)
+}
+
+// partitionOptions collects the options of a partition or subpartition.
+func partitionOptions(options []parser.IPartitionOptionContext) ddl.PartitionOptions {
+	result := ddl.PartitionOptions{}
+
+	for _, option := range options {
+		switch option := option.(type) {
+		case *parser.PartitionOptionEngineContext:
+			engine := trimName(option.EngineName().GetText())
+			result.Engine = &engine
+		case *parser.PartitionOptionCommentContext:
+			comment := unquoteString(option.GetComment().GetText())
+			result.Comment = &comment
+		case *parser.PartitionOptionDataDirectoryContext:
+			directory := unquoteString(option.GetDataDirectory().GetText())
+			result.DataDirectory = &directory
+		case *parser.PartitionOptionIndexDirectoryContext:
+			directory := unquoteString(option.GetIndexDirectory().GetText())
+			result.IndexDirectory = &directory
+		case *parser.PartitionOptionMaxRowsContext:
+			result.MaxRows = decimal(option.GetMaxRows())
+		case *parser.PartitionOptionMinRowsContext:
+			result.MinRows = decimal(option.GetMinRows())
+		case *parser.PartitionOptionTablespaceContext:
+			tablespace := trimName(option.GetTablespace().GetText())
+			result.Tablespace = &tablespace
+		case *parser.PartitionOptionNodeGroupContext:
+			nodeGroup := trimName(option.GetNodegroup().GetText())
+			result.NodeGroup = &nodeGroup
+		}
+	}
+
+	return result
+}
+
 // A CREATE TABLE statement is done processing.
-// Append the table to the list of parsed ones.
+// Append the table to the list of parsed ones, unless it is created by a routine.
 func (l *listener) ExitColumnCreateTable(ctx *parser.ColumnCreateTableContext) {
+	if l.RoutineDepth > 0 {
+		l.BuildingTable = nil
+
+		return
+	}
+
 	l.Tables = append(l.Tables, *l.BuildingTable)
+	l.Statements = append(l.Statements, ddl.Statement{Line: l.Line, CreateTable: l.BuildingTable})
 	l.BuildingTable = nil
 }
 
@@ -120,6 +601,23 @@ func (l *listener) ExitColumnDeclaration(ctx *parser.ColumnDeclarationContext) {
 	l.BuildingColumn = nil
 }
 
+// The definition of a column is visited. The grammar predates invisible columns, so VISIBLE and INVISIBLE
+// are read from the attributeChannel, and the last one wins.
+func (l *listener) EnterColumnDefinition(ctx *parser.ColumnDefinitionContext) {
+	if l.BuildingColumn == nil {
+		return
+	}
+
+	for _, token := range l.attributesOf(ctx) {
+		switch token.GetTokenType() {
+		case parser.MySqlLexerVISIBLE:
+			l.BuildingColumn.Invisible = false
+		case parser.MySqlLexerINVISIBLE:
+			l.BuildingColumn.Invisible = true
+		}
+	}
+}
+
 func (l *listener) EnterUid(ctx *parser.UidContext) { //nolint
 	if l.BuildingColumn != nil {
 		if len(l.BuildingColumn.Name) == 0 {
@@ -130,7 +628,297 @@ func (l *listener) EnterUid(ctx *parser.UidContext) { //nolint
 
 func (l *listener) EnterDataType(ctx *parser.DataTypeContext) {
 	if l.BuildingColumn != nil {
-		l.BuildingColumn.Type = ctx.GetText()
+		l.BuildingColumn.Type = dataType(ctx)
+	}
+}
+
+// dataType converts a type like VARCHAR(255) CHARACTER SET utf8mb4 into its structured form.
+func dataType(ctx *parser.DataTypeContext) ddl.DataType {
+	result := ddl.DataType{}
+	children := ctx.GetChildren()
+
+	// Some names consist of multiple keywords, e.g. DOUBLE PRECISION, NATIONAL CHAR VARYING or LONG VARBINARY.
+	name := []string{children[0].(antlr.ParseTree).GetText()}
+	national := ctx.NATIONAL() != nil
+
+	for _, child := range children[1:] {
+		token, ok := child.(antlr.TerminalNode)
+		if !ok {
+			break
+		}
+
+		tokenType := token.GetSymbol().GetTokenType()
+		if tokenType == parser.MySqlParserVARYING || tokenType == parser.MySqlParserPRECISION ||
+			tokenType == parser.MySqlParserVARCHAR || tokenType == parser.MySqlParserVARBINARY ||
+			(national && (tokenType == parser.MySqlParserCHAR || tokenType == parser.MySqlParserCHARACTER)) {
+			name = append(name, token.GetText())
+		} else {
+			break
+		}
+	}
+
+	result.Name = strings.ToUpper(strings.Join(name, " "))
+
+	if length := ctx.LengthOneDimension(); length != nil {
+		result.Length = decimal(length.(*parser.LengthOneDimensionContext).DecimalLiteral())
+	}
+
+	if length := ctx.LengthTwoDimension(); length != nil {
+		length := length.(*parser.LengthTwoDimensionContext)
+		result.Precision = decimal(length.DecimalLiteral(0))
+		result.Scale = decimal(length.DecimalLiteral(1))
+	}
+
+	if length := ctx.LengthTwoOptionalDimension(); length != nil {
+		length := length.(*parser.LengthTwoOptionalDimensionContext)
+		result.Precision = decimal(length.DecimalLiteral(0))
+		result.Scale = decimal(length.DecimalLiteral(1))
+	}
+
+	if collection := ctx.CollectionOptions(); collection != nil {
+		for _, value := range collection.(*parser.CollectionOptionsContext).AllSTRING_LITERAL() {
+			result.Values = append(result.Values, unquoteString(value.GetText()))
+		}
+	}
+
+	result.Unsigned = ctx.UNSIGNED() != nil
+	result.Zerofill = ctx.ZEROFILL() != nil
+
+	// BINARY may also be the name of the type itself, like in BINARY(16).
+	for _, binary := range ctx.AllBINARY() {
+		if binary != children[0] {
+			result.Binary = true
+		}
+	}
+
+	if ctx.CharsetName() != nil {
+		charset := trimName(ctx.CharsetName().GetText())
+		result.CharacterSet = &charset
+	}
+
+	if ctx.CollationName() != nil {
+		collation := trimName(ctx.CollationName().GetText())
+		result.Collate = &collation
+	}
+
+	return result
+}
+
+// decimal converts an optional decimal literal into an optional int.
+func decimal(ctx parser.IDecimalLiteralContext) *int {
+	if ctx == nil {
+		return nil
+	}
+
+	// The grammar only allows plain decimal literals here.
+	value, _ := strconv.Atoi(ctx.GetText())
+
+	return &value
+}
+
+// --- Callbacks for CREATE VIEW statements
+
+// A new CREATE VIEW statement was detected.
+func (l *listener) EnterCreateView(ctx *parser.CreateViewContext) {
+	schema, name := l.qualifiedName(ctx.FullId())
+	l.BuildingView = &ddl.View{
+		Schema:     schema,
+		Name:       name,
+		OrReplace:  ctx.REPLACE() != nil,
+		Definition: sourceText(ctx.SelectStatement()),
+	}
+
+	l.BuildingView.Columns = uidList(ctx.UidList())
+
+	if ctx.GetAlgType() != nil {
+		l.BuildingView.Algorithm = ddl.ViewAlgorithm(strings.ToUpper(ctx.GetAlgType().GetText()))
+	}
+
+	l.BuildingView.Definer = definer(ctx.OwnerStatement())
+
+	if ctx.GetSecContext() != nil {
+		l.BuildingView.Security = ddl.SQLSecurity(strings.ToUpper(ctx.GetSecContext().GetText()))
+	}
+
+	if ctx.CHECK() != nil {
+		// CASCADED is the default, if the level is not given.
+		l.BuildingView.CheckOption = ddl.CheckOptionCascaded
+		if ctx.GetCheckOption() != nil {
+			l.BuildingView.CheckOption = ddl.ViewCheckOption(strings.ToUpper(ctx.GetCheckOption().GetText()))
+		}
+	}
+}
+
+// definer returns the user of a DEFINER = 'user' clause, which is optional, so ctx may be nil.
+func definer(ctx parser.IOwnerStatementContext) *string {
+	if ctx == nil {
+		return nil
+	}
+
+	definer := "CURRENT_USER"
+	if owner := ctx.(*parser.OwnerStatementContext); owner.UserName() != nil {
+		definer = owner.UserName().GetText()
+	}
+
+	return &definer
+}
+
+// A CREATE VIEW statement is done processing.
+func (l *listener) ExitCreateView(ctx *parser.CreateViewContext) {
+	l.Views = append(l.Views, *l.BuildingView)
+	l.BuildingView = nil
+}
+
+// A table is referenced in a query. This includes tables in joins and subqueries.
+func (l *listener) EnterAtomTableItem(ctx *parser.AtomTableItemContext) {
+	if l.BuildingView != nil {
+		schema, name := l.tableName(ctx.TableName())
+		if schema != l.BuildingView.Schema {
+			name = schema + "." + name
+		}
+
+		for _, dependency := range l.BuildingView.Dependencies {
+			if dependency == name {
+				return
+			}
+		}
+
+		l.BuildingView.Dependencies = append(l.BuildingView.Dependencies, name)
+	}
+}
+
+// --- Callbacks for triggers and stored routines
+
+// A CREATE TRIGGER statement was detected.
+func (l *listener) EnterCreateTrigger(ctx *parser.CreateTriggerContext) {
+	// The trigger always belongs to the database of its table.
+	schema, name := splitName(ctx.GetThisTrigger())
+	tableSchema, table := l.tableName(ctx.TableName())
+
+	if schema == "" {
+		schema = tableSchema
+	}
+
+	trigger := ddl.Trigger{
+		Schema:  schema,
+		Name:    name,
+		Definer: definer(ctx.OwnerStatement()),
+		Timing:  ddl.TriggerTiming(strings.ToUpper(ctx.GetTriggerTime().GetText())),
+		Event:   ddl.TriggerEvent(strings.ToUpper(ctx.GetTriggerEvent().GetText())),
+		Table:   table,
+		Body:    sourceText(ctx.RoutineBody()),
+	}
+
+	if ctx.GetTriggerPlace() != nil {
+		trigger.Order = &ddl.TriggerOrder{
+			Position: ddl.TriggerPosition(strings.ToUpper(ctx.GetTriggerPlace().GetText())),
+			Trigger:  trimName(ctx.GetOtherTrigger().GetText()),
+		}
+	}
+
+	l.Triggers = append(l.Triggers, trigger)
+	l.RoutineDepth++
+}
+
+// A CREATE TRIGGER statement is done processing.
+func (l *listener) ExitCreateTrigger(ctx *parser.CreateTriggerContext) {
+	l.RoutineDepth--
+}
+
+// A CREATE PROCEDURE statement was detected.
+func (l *listener) EnterCreateProcedure(ctx *parser.CreateProcedureContext) {
+	schema, name := l.qualifiedName(ctx.FullId())
+	procedure := ddl.Routine{
+		Kind:    ddl.RoutineProcedure,
+		Schema:  schema,
+		Name:    name,
+		Definer: definer(ctx.OwnerStatement()),
+		Body:    sourceText(ctx.RoutineBody()),
+	}
+
+	for _, param := range ctx.AllProcedureParameter() {
+		param := param.(*parser.ProcedureParameterContext)
+		parameter := ddl.RoutineParameter{
+			Name: trimName(param.Uid().GetText()),
+			Type: dataType(param.DataType().(*parser.DataTypeContext)),
+		}
+
+		if param.GetDirection() != nil {
+			parameter.Direction = ddl.ParameterDirection(strings.ToUpper(param.GetDirection().GetText()))
+		}
+
+		procedure.Parameters = append(procedure.Parameters, parameter)
+	}
+
+	routineOptions(&procedure, ctx.AllRoutineOption())
+	l.Routines = append(l.Routines, procedure)
+	l.RoutineDepth++
+}
+
+// A CREATE PROCEDURE statement is done processing.
+func (l *listener) ExitCreateProcedure(ctx *parser.CreateProcedureContext) {
+	l.RoutineDepth--
+}
+
+// A CREATE FUNCTION statement was detected.
+func (l *listener) EnterCreateFunction(ctx *parser.CreateFunctionContext) {
+	returns := dataType(ctx.DataType().(*parser.DataTypeContext))
+	schema, name := l.qualifiedName(ctx.FullId())
+	function := ddl.Routine{
+		Kind:    ddl.RoutineFunction,
+		Schema:  schema,
+		Name:    name,
+		Definer: definer(ctx.OwnerStatement()),
+		Returns: &returns,
+	}
+
+	// A function may consist of a single RETURN statement instead of a full body.
+	if ctx.RoutineBody() != nil {
+		function.Body = sourceText(ctx.RoutineBody())
+	} else {
+		function.Body = sourceText(ctx.ReturnStatement())
+	}
+
+	for _, param := range ctx.AllFunctionParameter() {
+		param := param.(*parser.FunctionParameterContext)
+		function.Parameters = append(function.Parameters, ddl.RoutineParameter{
+			Name: trimName(param.Uid().GetText()),
+			Type: dataType(param.DataType().(*parser.DataTypeContext)),
+		})
+	}
+
+	routineOptions(&function, ctx.AllRoutineOption())
+	l.Routines = append(l.Routines, function)
+	l.RoutineDepth++
+}
+
+// A CREATE FUNCTION statement is done processing.
+func (l *listener) ExitCreateFunction(ctx *parser.CreateFunctionContext) {
+	l.RoutineDepth--
+}
+
+// routineOptions applies the characteristics like DETERMINISTIC to a procedure or function.
+func routineOptions(routine *ddl.Routine, options []parser.IRoutineOptionContext) {
+	for _, option := range options {
+		switch option := option.(type) {
+		case *parser.RoutineCommentContext:
+			comment := unquoteString(option.STRING_LITERAL().GetText())
+			routine.Comment = &comment
+		case *parser.RoutineBehaviorContext:
+			routine.Deterministic = option.NOT() == nil
+		case *parser.RoutineDataContext:
+			// GetText would join the keywords without spaces.
+			var words []string
+			for _, child := range option.GetChildren() {
+				words = append(words, strings.ToUpper(child.(antlr.ParseTree).GetText()))
+			}
+
+			routine.DataAccess = ddl.RoutineDataAccess(strings.Join(words, " "))
+		case *parser.RoutineSecurityContext:
+			routine.Security = ddl.SQLSecurity(strings.ToUpper(option.GetContext().GetText()))
+		}
+
+		// LANGUAGE SQL is the only language, so there is nothing to remember.
 	}
 }
 
@@ -139,13 +927,17 @@ func (l *listener) EnterDataType(ctx *parser.DataTypeContext) {
 // An ALTER TABLE statement was detected. Prepare the table name, so that it is available
 // for saving the smaller statements.
 func (l *listener) EnterAlterTable(ctx *parser.AlterTableContext) {
-	tableName := ctx.TableName().GetText()
-	tableName = trimName(tableName)
-	l.BuildingTable = &ddl.Table{Name: tableName}
+	schema, name := l.tableName(ctx.TableName())
+	l.BuildingTable = &ddl.Table{Schema: schema, Name: name}
 }
 
 // An ALTER TABLE statement was parsed, reset the table.
 func (l *listener) ExitAlterTable(ctx *parser.AlterTableContext) {
+	if l.PendingRename != nil {
+		l.addSchemaStatement(*l.PendingRename)
+		l.PendingRename = nil
+	}
+
 	l.BuildingTable = nil
 }
 
@@ -157,6 +949,7 @@ func (l *listener) EnterAlterByAddColumn(ctx *parser.AlterByAddColumnContext) {
 // We parsed an ADD COLUMN statement. Save it.
 func (l *listener) ExitAlterByAddColumn(ctx *parser.AlterByAddColumnContext) {
 	addStatement := ddl.AlterAddColumn{
+		Schema: l.BuildingTable.Schema,
 		Table:  l.BuildingTable.Name,
 		Column: *l.BuildingColumn,
 	}
@@ -171,13 +964,72 @@ func (l *listener) ExitAlterByAddColumn(ctx *parser.AlterByAddColumnContext) {
 		addStatement.First = true
 	}
 
-	l.AlterStatements = append(l.AlterStatements, addStatement)
+	l.addAlterStatement(addStatement)
 	l.BuildingColumn = nil
 }
 
+// Prepare a new MODIFY COLUMN statement.
+func (l *listener) EnterAlterByModifyColumn(ctx *parser.AlterByModifyColumnContext) {
+	l.BuildingColumn = &ddl.Column{Name: trimName(ctx.Uid(0).GetText())}
+}
+
+// We parsed a MODIFY COLUMN statement. Save it.
+func (l *listener) ExitAlterByModifyColumn(ctx *parser.AlterByModifyColumnContext) {
+	modifyStatement := ddl.AlterModifyColumn{
+		Schema: l.BuildingTable.Schema,
+		Table:  l.BuildingTable.Name,
+		Column: *l.BuildingColumn,
+		First:  ctx.FIRST() != nil,
+	}
+
+	if ctx.AFTER() != nil {
+		afterColumn := trimName(ctx.Uid(1).GetText())
+		modifyStatement.After = &afterColumn
+	}
+
+	l.addAlterStatement(modifyStatement)
+	l.BuildingColumn = nil
+}
+
+// Prepare a new CHANGE COLUMN statement. The name must be set here, because
+// the first name in the statement is the old one.
+func (l *listener) EnterAlterByChangeColumn(ctx *parser.AlterByChangeColumnContext) {
+	l.BuildingColumn = &ddl.Column{Name: trimName(ctx.GetNewColumn().GetText())}
+}
+
+// We parsed a CHANGE COLUMN statement. Save it.
+func (l *listener) ExitAlterByChangeColumn(ctx *parser.AlterByChangeColumnContext) {
+	changeStatement := ddl.AlterChangeColumn{
+		Schema:  l.BuildingTable.Schema,
+		Table:   l.BuildingTable.Name,
+		OldName: trimName(ctx.GetOldColumn().GetText()),
+		Column:  *l.BuildingColumn,
+		First:   ctx.FIRST() != nil,
+	}
+
+	if ctx.GetAfterColumn() != nil {
+		afterColumn := trimName(ctx.GetAfterColumn().GetText())
+		changeStatement.After = &afterColumn
+	}
+
+	l.addAlterStatement(changeStatement)
+	l.BuildingColumn = nil
+}
+
+// We parsed a RENAME COLUMN statement. Save it.
+func (l *listener) EnterAlterByRenameColumn(ctx *parser.AlterByRenameColumnContext) {
+	l.addAlterStatement(ddl.AlterRenameColumn{
+		Schema:  l.BuildingTable.Schema,
+		Table:   l.BuildingTable.Name,
+		OldName: trimName(ctx.GetOldColumn().GetText()),
+		NewName: trimName(ctx.GetNewColumn().GetText()),
+	})
+}
+
 // We parsed a DROP COLUMN statement. Save it.
 func (l *listener) ExitAlterByDropColumn(ctx *parser.AlterByDropColumnContext) {
-	l.AlterStatements = append(l.AlterStatements, ddl.AlterDropColumn{
+	l.addAlterStatement(ddl.AlterDropColumn{
+		Schema: l.BuildingTable.Schema,
 		Table:  l.BuildingTable.Name,
 		Column: trimName(ctx.Uid().GetText()),
 	})
@@ -186,32 +1038,77 @@ func (l *listener) ExitAlterByDropColumn(ctx *parser.AlterByDropColumnContext) {
 func (l *listener) EnterCreateIndex(ctx *parser.CreateIndexContext) {
 	indexName := ctx.Uid().GetText()
 	indexName = trimName(indexName)
-	onTableName := ctx.TableName().GetText()
-	onTableName = trimName(onTableName)
+	schema, onTableName := l.tableName(ctx.TableName())
 
-	// An index can be created on many columns. To keep things simple, we will only support one column.
-	// This is analogous to the limitations for FOREIGN KEY constraints. See EnterForeignKeyTableConstraint.
-	columnName := ctx.IndexColumnNames().GetText()
-	columnName = trimName(strings.Trim(columnName, "()"))
-
-	l.AlterStatements = append(l.AlterStatements, ddl.AlterAddIndex{
-		Table:  onTableName,
-		Name:   indexName,
-		Column: columnName,
-		Unique: ctx.UNIQUE() != nil,
+	l.addAlterStatement(ddl.AlterAddIndex{
+		Schema:  schema,
+		Table:   onTableName,
+		Name:    indexName,
+		Parts:   indexParts(ctx.IndexColumnNames()),
+		Unique:  ctx.UNIQUE() != nil,
+		Kind:    indexKind(ctx.GetIndexCategory()),
+		Options: indexOptions(ctx.IndexType(), ctx.AllIndexOption()),
 	})
 }
 
+// indexKind converts the optional FULLTEXT or SPATIAL keyword of an index.
+func indexKind(token antlr.Token) ddl.IndexKind {
+	if token == nil {
+		return ""
+	}
+
+	switch token.GetTokenType() {
+	case parser.MySqlParserFULLTEXT:
+		return ddl.IndexFulltext
+	case parser.MySqlParserSPATIAL:
+		return ddl.IndexSpatial
+	default:
+		// UNIQUE is handled separately.
+		return ""
+	}
+}
+
+// An ALTER TABLE 'table' ADD INDEX statement was parsed.
+func (l *listener) EnterAlterByAddIndex(ctx *parser.AlterByAddIndexContext) {
+	l.addIndex(ctx.Uid(), false, "", ctx.IndexColumnNames(), indexOptions(ctx.IndexType(), ctx.AllIndexOption()))
+}
+
+// An ALTER TABLE 'table' ADD FULLTEXT or ADD SPATIAL statement was parsed.
+func (l *listener) EnterAlterByAddSpecialIndex(ctx *parser.AlterByAddSpecialIndexContext) {
+	l.addIndex(ctx.Uid(), false, indexKind(ctx.GetKeyType()), ctx.IndexColumnNames(),
+		indexOptions(nil, ctx.AllIndexOption()))
+}
+
+// addIndex saves an index that is added by ALTER TABLE. If it has no name, MySQL names it
+// after its first column, when it is applied.
+func (l *listener) addIndex(name parser.IUidContext, unique bool, kind ddl.IndexKind,
+	columns parser.IIndexColumnNamesContext, options ddl.IndexOptions) {
+	add := ddl.AlterAddIndex{
+		Schema:  l.BuildingTable.Schema,
+		Table:   l.BuildingTable.Name,
+		Parts:   indexParts(columns),
+		Unique:  unique,
+		Kind:    kind,
+		Options: options,
+	}
+
+	if name != nil {
+		add.Name = trimName(name.GetText())
+	}
+
+	l.addAlterStatement(add)
+}
+
 // A DROP INDEX 'index' ON 'table' statement was parsed.
 func (l *listener) EnterDropIndex(ctx *parser.DropIndexContext) {
 	indexName := ctx.Uid().GetText()
 	indexName = trimName(indexName)
-	onTableName := ctx.TableName().GetText()
-	onTableName = trimName(onTableName)
+	schema, onTableName := l.tableName(ctx.TableName())
 
-	l.AlterStatements = append(l.AlterStatements, ddl.AlterDropIndex{
-		Table: onTableName,
-		Index: indexName,
+	l.addAlterStatement(ddl.AlterDropIndex{
+		Schema: schema,
+		Table:  onTableName,
+		Index:  indexName,
 	})
 }
 
@@ -219,15 +1116,238 @@ func (l *listener) EnterDropIndex(ctx *parser.DropIndexContext) {
 func (l *listener) EnterAlterByDropIndex(ctx *parser.AlterByDropIndexContext) {
 	indexName := ctx.Uid().GetText()
 	indexName = trimName(indexName)
-	onTableName := l.BuildingTable.Name
-	l.AlterStatements = append(l.AlterStatements, ddl.AlterDropIndex{
-		Table: onTableName,
-		Index: indexName,
+	l.addAlterStatement(ddl.AlterDropIndex{
+		Schema: l.BuildingTable.Schema,
+		Table:  l.BuildingTable.Name,
+		Index:  indexName,
+	})
+}
+
+// An ALTER TABLE 'table' ADD PRIMARY KEY statement was parsed.
+func (l *listener) EnterAlterByAddPrimaryKey(ctx *parser.AlterByAddPrimaryKeyContext) {
+	primaryKey := ddl.PrimaryKeyConstraint{
+		Parts:   indexParts(ctx.IndexColumnNames()),
+		Options: indexOptions(ctx.IndexType(), ctx.AllIndexOption()),
+	}
+
+	if ctx.GetName() != nil {
+		constraintName := trimName(ctx.GetName().GetText())
+		primaryKey.Name = &constraintName
+	}
+
+	l.addAlterStatement(ddl.AlterAddPrimaryKey{
+		Schema:     l.BuildingTable.Schema,
+		Table:      l.BuildingTable.Name,
+		PrimaryKey: primaryKey,
+	})
+}
+
+// An ALTER TABLE 'table' DROP PRIMARY KEY statement was parsed.
+func (l *listener) EnterAlterByDropPrimaryKey(ctx *parser.AlterByDropPrimaryKeyContext) {
+	l.addAlterStatement(ddl.AlterDropPrimaryKey{
+		Schema: l.BuildingTable.Schema,
+		Table:  l.BuildingTable.Name,
+	})
+}
+
+// An ALTER TABLE 'table' ADD UNIQUE statement was parsed, which is the same as CREATE UNIQUE INDEX.
+// Like for table level constraints, the name of the index takes precedence.
+func (l *listener) EnterAlterByAddUniqueKey(ctx *parser.AlterByAddUniqueKeyContext) {
+	name := ctx.GetIndexName()
+	if name == nil {
+		name = ctx.GetName()
+	}
+
+	l.addIndex(name, true, "", ctx.IndexColumnNames(), indexOptions(ctx.IndexType(), ctx.AllIndexOption()))
+}
+
+// Prepare a new ADD FOREIGN KEY statement. The reference is filled in by EnterReferenceDefinition.
+func (l *listener) EnterAlterByAddForeignKey(ctx *parser.AlterByAddForeignKeyContext) {
+	l.BuildingForeignKeyConstraint = &ddl.ForeignKeyConstraint{
+		Columns: indexColumnNames(ctx.IndexColumnNames()),
+	}
+
+	if ctx.GetName() != nil {
+		constraintName := trimName(ctx.GetName().GetText())
+		l.BuildingForeignKeyConstraint.Name = &constraintName
+	}
+}
+
+// We parsed an ADD FOREIGN KEY statement. Save it.
+func (l *listener) ExitAlterByAddForeignKey(ctx *parser.AlterByAddForeignKeyContext) {
+	l.addAlterStatement(ddl.AlterAddForeignKey{
+		Schema:     l.BuildingTable.Schema,
+		Table:      l.BuildingTable.Name,
+		ForeignKey: *l.BuildingForeignKeyConstraint,
+	})
+	l.BuildingForeignKeyConstraint = nil
+}
+
+// An ALTER TABLE 'table' DROP FOREIGN KEY 'name' statement was parsed.
+func (l *listener) EnterAlterByDropForeignKey(ctx *parser.AlterByDropForeignKeyContext) {
+	l.addAlterStatement(ddl.AlterDropForeignKey{
+		Schema: l.BuildingTable.Schema,
+		Table:  l.BuildingTable.Name,
+		Name:   trimName(ctx.Uid().GetText()),
+	})
+}
+
+// An ALTER TABLE 'table' ADD CHECK (expression) statement was parsed.
+func (l *listener) EnterAlterByAddCheckTableConstraint(ctx *parser.AlterByAddCheckTableConstraintContext) {
+	l.addAlterStatement(ddl.AlterAddCheck{
+		Schema: l.BuildingTable.Schema,
+		Table:  l.BuildingTable.Name,
+		Check:  l.checkConstraint(ctx, ctx.GetName(), ctx.Expression()),
+	})
+}
+
+// An ALTER TABLE 'table' DROP CONSTRAINT 'name' or DROP CHECK 'name' statement was parsed.
+func (l *listener) EnterAlterByDropConstraintCheck(ctx *parser.AlterByDropConstraintCheckContext) {
+	l.addAlterStatement(ddl.AlterDropConstraint{
+		Schema: l.BuildingTable.Schema,
+		Table:  l.BuildingTable.Name,
+		Name:   trimName(ctx.Uid().GetText()),
+		Check:  ctx.CHECK() != nil,
+	})
+}
+
+// An ALTER TABLE 'table' ENGINE=... statement was parsed, which changes the table options.
+func (l *listener) EnterAlterByTableOption(ctx *parser.AlterByTableOptionContext) {
+	l.addAlterStatement(ddl.AlterTableOptions{
+		Schema:  l.BuildingTable.Schema,
+		Table:   l.BuildingTable.Name,
+		Options: tableOptions(ctx.AllTableOption()),
+	})
+}
+
+// An ALTER TABLE 'table' RENAME TO 'new' statement was parsed. Like RENAME TABLE, it is
+// a schema statement, because foreign keys of other tables refer to the renamed table.
+// The other parts of the statement still refer to the old name, so it is saved last.
+func (l *listener) EnterAlterByRename(ctx *parser.AlterByRenameContext) {
+	rename := ddl.RenameTable{
+		Schema:    l.BuildingTable.Schema,
+		Table:     l.BuildingTable.Name,
+		NewSchema: l.Database,
+	}
+
+	if ctx.FullId() != nil {
+		rename.NewSchema, rename.NewTable = l.qualifiedName(ctx.FullId())
+	} else {
+		rename.NewTable = trimName(ctx.Uid().GetText())
+	}
+
+	l.PendingRename = &rename
+}
+
+// --- Callbacks for RENAME TABLE, DROP TABLE and TRUNCATE TABLE
+
+// A RENAME TABLE statement was parsed. Each of its renames is saved on its own.
+// An unqualified new name is in the current database, like in MySQL.
+func (l *listener) EnterRenameTableClause(ctx *parser.RenameTableClauseContext) {
+	schema, name := l.tableName(ctx.TableName(0))
+	newSchema, newName := l.tableName(ctx.TableName(1))
+
+	l.addSchemaStatement(ddl.RenameTable{
+		Schema:    schema,
+		Table:     name,
+		NewSchema: newSchema,
+		NewTable:  newName,
+	})
+}
+
+// A DROP TABLE statement was parsed. Each of its tables is saved on its own.
+func (l *listener) EnterDropTable(ctx *parser.DropTableContext) {
+	for _, tableName := range ctx.Tables().(*parser.TablesContext).AllTableName() {
+		schema, name := l.tableName(tableName)
+
+		l.addSchemaStatement(ddl.DropTable{
+			Schema:   schema,
+			Table:    name,
+			IfExists: ctx.IfExists() != nil,
+		})
+	}
+}
+
+// A TRUNCATE TABLE statement was parsed.
+func (l *listener) EnterTruncateTable(ctx *parser.TruncateTableContext) {
+	schema, name := l.tableName(ctx.TableName())
+
+	l.addSchemaStatement(ddl.TruncateTable{
+		Schema: schema,
+		Table:  name,
 	})
 }
 
 // --- Callbacks for building constraints
 
+// A table level PRIMARY KEY constraint, which might be composite.
+func (l *listener) EnterPrimaryKeyTableConstraint(ctx *parser.PrimaryKeyTableConstraintContext) {
+	primaryKey := &ddl.PrimaryKeyConstraint{
+		Parts:   indexParts(ctx.IndexColumnNames()),
+		Options: indexOptions(ctx.IndexType(), ctx.AllIndexOption()),
+	}
+
+	if ctx.GetName() != nil {
+		constraintName := trimName(ctx.GetName().GetText())
+		primaryKey.Name = &constraintName
+	}
+
+	l.BuildingTable.PrimaryKey = primaryKey
+}
+
+// A table level UNIQUE constraint, which might be composite.
+func (l *listener) EnterUniqueKeyTableConstraint(ctx *parser.UniqueKeyTableConstraintContext) {
+	unique := ddl.UniqueConstraint{
+		Parts:   indexParts(ctx.IndexColumnNames()),
+		Options: indexOptions(ctx.IndexType(), ctx.AllIndexOption()),
+	}
+
+	// The name of the index takes precedence over the name of the constraint.
+	if ctx.GetIndex() != nil {
+		indexName := trimName(ctx.GetIndex().GetText())
+		unique.Name = &indexName
+	} else if ctx.GetName() != nil {
+		constraintName := trimName(ctx.GetName().GetText())
+		unique.Name = &constraintName
+	}
+
+	l.BuildingTable.UniqueConstraints = append(l.BuildingTable.UniqueConstraints, unique)
+}
+
+// A table level CHECK constraint.
+func (l *listener) EnterCheckTableConstraint(ctx *parser.CheckTableConstraintContext) {
+	l.BuildingTable.Checks = append(l.BuildingTable.Checks, l.checkConstraint(ctx, ctx.GetName(), ctx.Expression()))
+}
+
+// A column level CHECK constraint.
+func (l *listener) EnterCheckColumnConstraint(ctx *parser.CheckColumnConstraintContext) {
+	if l.BuildingColumn != nil {
+		l.BuildingColumn.Checks = append(l.BuildingColumn.Checks, l.checkConstraint(ctx, ctx.GetName(), ctx.Expression()))
+	}
+}
+
+// checkConstraint builds a CHECK constraint from its optional name and its expression. The grammar predates
+// MySQL 8.0.16, so a trailing [NOT] ENFORCED is read from the attributeChannel after the constraint.
+func (l *listener) checkConstraint(ctx antlr.ParserRuleContext, name parser.IUidContext,
+	expression parser.IExpressionContext) ddl.CheckConstraint {
+	check := ddl.CheckConstraint{
+		Expression: sourceText(expression),
+	}
+
+	for _, token := range l.attributesAfter(ctx) {
+		if token.GetTokenType() == parser.MySqlLexerNOT {
+			check.NotEnforced = true
+		}
+	}
+
+	if name != nil {
+		constraintName := trimName(name.GetText())
+		check.Name = &constraintName
+	}
+
+	return check
+}
+
 // A FOREIGN KEY is visited.
 func (l *listener) EnterForeignKeyTableConstraint(ctx *parser.ForeignKeyTableConstraintContext) {
 	l.BuildingForeignKeyConstraint = &ddl.ForeignKeyConstraint{}
@@ -237,30 +1357,114 @@ func (l *listener) EnterForeignKeyTableConstraint(ctx *parser.ForeignKeyTableCon
 		l.BuildingForeignKeyConstraint.Name = &constraintName
 	}
 
-	// A FOREIGN KEY constraint can reference multiple columns ("composite key").
-	// For the sake of keeping it simple, we will assume that only a single column is specified.
-	column := ctx.IndexColumnNames().GetText()
-	column = trimName(strings.Trim(column, "()"))
-	l.BuildingForeignKeyConstraint.Column = column
+	l.BuildingForeignKeyConstraint.Columns = indexColumnNames(ctx.IndexColumnNames())
 }
 
 // We can get the names of what a FOREIGN KEY is referencing here.
 func (l *listener) EnterReferenceDefinition(ctx *parser.ReferenceDefinitionContext) {
 	if l.BuildingForeignKeyConstraint != nil {
-		l.BuildingForeignKeyConstraint.ReferenceTable = trimName(ctx.TableName().GetText())
-		// FOREIGN KEYs can be composite. See above on why we ignore that.
-		column := ctx.IndexColumnNames().GetText()
-		column = trimName(strings.Trim(column, "()"))
-		l.BuildingForeignKeyConstraint.ReferenceColumn = column
-		l.BuildingTable.ForeignKeys = append(l.BuildingTable.ForeignKeys, *l.BuildingForeignKeyConstraint)
-		l.BuildingForeignKeyConstraint = nil
+		// An unqualified reference is resolved in the database of the constraining table.
+		schema, table := splitName(ctx.TableName().(*parser.TableNameContext).FullId())
+		if schema == "" {
+			schema = l.BuildingTable.Schema
+		}
+
+		l.BuildingForeignKeyConstraint.ReferenceSchema = schema
+		l.BuildingForeignKeyConstraint.ReferenceTable = table
+		l.BuildingForeignKeyConstraint.ReferenceColumns = indexColumnNames(ctx.IndexColumnNames())
+
+		if ctx.GetMatchType() != nil {
+			l.BuildingForeignKeyConstraint.Match = ddl.MatchType(strings.ToUpper(ctx.GetMatchType().GetText()))
+		}
+
+		if ctx.ReferenceAction() != nil {
+			action := ctx.ReferenceAction().(*parser.ReferenceActionContext)
+			l.BuildingForeignKeyConstraint.OnDelete = referenceAction(action.GetOnDelete())
+			l.BuildingForeignKeyConstraint.OnUpdate = referenceAction(action.GetOnUpdate())
+		}
+
+	}
+}
+
+// A FOREIGN KEY of a CREATE TABLE statement was parsed. Save it.
+func (l *listener) ExitForeignKeyTableConstraint(ctx *parser.ForeignKeyTableConstraintContext) {
+	l.BuildingTable.ForeignKeys = append(l.BuildingTable.ForeignKeys, *l.BuildingForeignKeyConstraint)
+	l.BuildingForeignKeyConstraint = nil
+}
+
+// referenceAction converts the action of an ON DELETE or ON UPDATE clause.
+// Returns an empty action if the clause is not present.
+func referenceAction(ctx parser.IReferenceControlTypeContext) ddl.ReferenceAction {
+	if ctx == nil {
+		return ""
+	}
+
+	control := ctx.(*parser.ReferenceControlTypeContext)
+
+	switch {
+	case control.RESTRICT() != nil:
+		return ddl.ReferenceRestrict
+	case control.CASCADE() != nil:
+		return ddl.ReferenceCascade
+	case control.SET() != nil:
+		return ddl.ReferenceSetNull
+	default:
+		return ddl.ReferenceNoAction
 	}
 }
 
-// NOT NULL constraint.
+// NOT NULL or NULL constraint.
 func (l *listener) EnterNullColumnConstraint(ctx *parser.NullColumnConstraintContext) {
 	if l.BuildingColumn != nil {
-		l.BuildingColumn.NotNull = true
+		if ctx.NullNotnull().(*parser.NullNotnullContext).NOT() != nil {
+			l.BuildingColumn.NotNull = true
+		} else {
+			l.BuildingColumn.Null = true
+		}
+	}
+}
+
+// AUTO_INCREMENT or ON UPDATE constraint, which share a rule in the grammar.
+func (l *listener) EnterAutoIncrementColumnConstraint(ctx *parser.AutoIncrementColumnConstraintContext) {
+	if l.BuildingColumn != nil {
+		if ctx.AUTO_INCREMENT() != nil {
+			l.BuildingColumn.AutoIncrement = true
+		} else {
+			onUpdate := ctx.CurrentTimestamp().GetText()
+			l.BuildingColumn.OnUpdate = &onUpdate
+		}
+	}
+}
+
+// A generated column, like in AS (a + b) STORED.
+func (l *listener) EnterGeneratedColumnConstraint(ctx *parser.GeneratedColumnConstraintContext) {
+	if l.BuildingColumn != nil {
+		// VIRTUAL is the default, if the storage is not given.
+		storage := ddl.GeneratedVirtual
+		if ctx.STORED() != nil {
+			storage = ddl.GeneratedStored
+		}
+
+		l.BuildingColumn.Generated = &ddl.GeneratedColumn{
+			Expression: sourceText(ctx.Expression()),
+			Storage:    storage,
+		}
+	}
+}
+
+// COMMENT constraint.
+func (l *listener) EnterCommentColumnConstraint(ctx *parser.CommentColumnConstraintContext) {
+	if l.BuildingColumn != nil {
+		comment := unquoteString(ctx.STRING_LITERAL().GetText())
+		l.BuildingColumn.Comment = &comment
+	}
+}
+
+// COLLATE constraint. The collation is stored in the data type, where it may also be declared.
+func (l *listener) EnterCollateColumnConstraint(ctx *parser.CollateColumnConstraintContext) {
+	if l.BuildingColumn != nil {
+		collation := trimName(ctx.CollationName().GetText())
+		l.BuildingColumn.Type.Collate = &collation
 	}
 }
 
@@ -281,12 +1485,28 @@ func (l *listener) EnterUniqueKeyColumnConstraint(ctx *parser.UniqueKeyColumnCon
 // DEFAULT constraint.
 func (l *listener) EnterDefaultColumnConstraint(ctx *parser.DefaultColumnConstraintContext) {
 	if l.BuildingColumn != nil {
-		defaultValue := ctx.DefaultValue().GetText()
+		value := ctx.DefaultValue().(*parser.DefaultValueContext)
+		defaultValue := ""
+
+		// The grammar allows an ON UPDATE clause as part of the default value.
+		// It is a separate attribute of the column, so split it off.
+		for _, child := range value.GetChildren() {
+			if token, ok := child.(antlr.TerminalNode); ok && token.GetSymbol().GetTokenType() == parser.MySqlParserON {
+				timestamps := value.AllCurrentTimestamp()
+				onUpdate := timestamps[len(timestamps)-1].GetText()
+				l.BuildingColumn.OnUpdate = &onUpdate
+
+				break
+			}
+
+			defaultValue += child.(antlr.ParseTree).GetText()
+		}
+
 		l.BuildingColumn.Default = &defaultValue
 	}
 }
 
-// A KEY constraint, which represents an index an a column.
+// A KEY constraint, which represents an index on one or more columns.
 func (l *listener) EnterSimpleIndexDeclaration(ctx *parser.SimpleIndexDeclarationContext) {
 	key := ddl.Key{}
 
@@ -296,11 +1516,69 @@ func (l *listener) EnterSimpleIndexDeclaration(ctx *parser.SimpleIndexDeclaratio
 		key.Name = &keyName
 	}
 
-	// An index can be created on many columns. To keep things simple, we will only support one column.
-	// This is analogous to the limitations for FOREIGN KEY constraints. See EnterForeignKeyTableConstraint.
-	columnName := ctx.IndexColumnNames().GetText()
-	columnName = trimName(strings.Trim(columnName, "()"))
-	key.OnColumn = columnName
+	key.Parts = indexParts(ctx.IndexColumnNames())
+	key.Options = indexOptions(ctx.IndexType(), ctx.AllIndexOption())
+
+	l.BuildingTable.Keys = append(l.BuildingTable.Keys, key)
+}
+
+// A FULLTEXT or SPATIAL index, which is declared inside of CREATE TABLE.
+func (l *listener) EnterSpecialIndexDeclaration(ctx *parser.SpecialIndexDeclarationContext) {
+	key := ddl.Key{
+		Kind:    ddl.IndexFulltext,
+		Parts:   indexParts(ctx.IndexColumnNames()),
+		Options: indexOptions(nil, ctx.AllIndexOption()),
+	}
+
+	if ctx.SPATIAL() != nil {
+		key.Kind = ddl.IndexSpatial
+	}
+
+	if ctx.Uid() != nil {
+		keyName := trimName(ctx.Uid().GetText())
+		key.Name = &keyName
+	}
 
 	l.BuildingTable.Keys = append(l.BuildingTable.Keys, key)
 }
+
+// indexOptions collects the options of an index. The index type may be given before the
+// columns or as an option after them, so both places are considered. using may be nil.
+func indexOptions(using parser.IIndexTypeContext, options []parser.IIndexOptionContext) ddl.IndexOptions {
+	result := ddl.IndexOptions{}
+
+	if using != nil {
+		result.Type = indexType(using)
+	}
+
+	for _, option := range options {
+		option := option.(*parser.IndexOptionContext)
+
+		switch {
+		case option.KEY_BLOCK_SIZE() != nil:
+			size := strings.ToUpper(option.FileSizeLiteral().GetText())
+			result.KeyBlockSize = &size
+		case option.IndexType() != nil:
+			result.Type = indexType(option.IndexType())
+		case option.PARSER() != nil:
+			parserName := trimName(option.Uid().GetText())
+			result.Parser = &parserName
+		case option.COMMENT() != nil:
+			comment := unquoteString(option.STRING_LITERAL().GetText())
+			result.Comment = &comment
+		default:
+			result.Invisible = option.INVISIBLE() != nil
+		}
+	}
+
+	return result
+}
+
+// indexType returns the data structure of a USING BTREE or USING HASH clause.
+func indexType(ctx parser.IIndexTypeContext) ddl.IndexType {
+	if ctx.(*parser.IndexTypeContext).HASH() != nil {
+		return ddl.IndexHash
+	}
+
+	return ddl.IndexBTree
+}
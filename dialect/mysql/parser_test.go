@@ -77,29 +77,670 @@ func TestParseAlter(t *testing.T) {
 	}
 }
 
+func TestParseComposite(t *testing.T) {
+	sql := loadSql("composite.sql")
+
+	expectedTables := expectedCompositeTables()
+
+	actualResult, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actualResult.Tables) != len(expectedTables) {
+		t.Fatalf("Expected %v tables, but got %v", len(expectedTables), len(actualResult.Tables))
+	}
+
+	for i := 0; i < len(expectedTables); i++ {
+		actual := actualResult.Tables[i]
+		expected := expectedTables[i]
+		internal.DiffCompare(t, actual, expected, fmt.Sprintf("table %s", actual.Name))
+	}
+}
+
+func TestParseShop(t *testing.T) {
+	sql := loadSql("shop.sql")
+
+	expectedTables := expectedShopTables()
+
+	actualResult, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actualResult.Tables) != len(expectedTables) {
+		t.Fatalf("Expected %v tables, but got %v", len(expectedTables), len(actualResult.Tables))
+	}
+
+	for i := 0; i < len(expectedTables); i++ {
+		actual := actualResult.Tables[i]
+		expected := expectedTables[i]
+		internal.DiffCompare(t, actual, expected, fmt.Sprintf("table %s", actual.Name))
+	}
+}
+
+func TestParseViews(t *testing.T) {
+	sql := loadSql("views.sql")
+
+	actualResult, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedViews := []ddl.View{
+		{
+			Name:         "CustomerNames",
+			Definition:   "SELECT Name FROM Customer WHERE Name IS NOT NULL",
+			CheckOption:  ddl.CheckOptionLocal,
+			Dependencies: []string{"Customer"},
+		},
+		{
+			Name:      "BigSpenders",
+			OrReplace: true,
+			Columns:   []string{"Name", "Total"},
+			Algorithm: ddl.AlgorithmMerge,
+			Definer:   s("CURRENT_USER"),
+			Security:  ddl.SecurityInvoker,
+			Definition: "SELECT c.Name, SUM(p.Amount)\n" +
+				"    FROM Customer c JOIN Purchase p ON p.Customer = c.Id\n" +
+				"    WHERE c.Id IN (SELECT Customer FROM Purchase WHERE Amount > 100)\n" +
+				"    GROUP BY c.Name",
+			Dependencies: []string{"Customer", "Purchase"},
+		},
+	}
+
+	if len(actualResult.Views) != len(expectedViews) {
+		t.Fatalf("Expected %v views, but got %v", len(expectedViews), len(actualResult.Views))
+	}
+
+	for i := 0; i < len(expectedViews); i++ {
+		internal.DiffCompare(t, actualResult.Views[i], expectedViews[i], fmt.Sprintf("view %s", expectedViews[i].Name))
+	}
+}
+
+func TestParseRoutines(t *testing.T) {
+	sql := loadSql("routines.sql")
+
+	actualResult, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actualResult.Tables) != 2 {
+		t.Fatalf("Expected 2 tables, but got %v", len(actualResult.Tables))
+	}
+
+	// Statements in the body of a routine only run when it is called, so they do not change the schema.
+	if len(actualResult.AlterStatements) != 0 || len(actualResult.SchemaStatements) != 0 {
+		t.Fatalf("Expected no statements of routine bodies, but got %v and %v",
+			actualResult.AlterStatements, actualResult.SchemaStatements)
+	}
+
+	if len(actualResult.Statements) != 2 || actualResult.Statements[1].Line != 8 {
+		t.Fatalf("Expected the CREATE TABLE statements in line 1 and 8, but got %v", actualResult.Statements)
+	}
+
+	expectedTriggers := []ddl.Trigger{
+		{
+			Name:   "BeforeAccountUpdate",
+			Timing: ddl.TriggerBefore,
+			Event:  ddl.TriggerUpdate,
+			Table:  "Account",
+			Body:   "BEGIN\n    SET NEW.Changed = NOW();\nEND",
+		},
+		{
+			Name:    "AfterAccountUpdate",
+			Definer: s("'admin'@'localhost'"),
+			Timing:  ddl.TriggerAfter,
+			Event:   ddl.TriggerUpdate,
+			Table:   "Account",
+			Order: &ddl.TriggerOrder{
+				Position: ddl.TriggerFollows,
+				Trigger:  "BeforeAccountUpdate",
+			},
+			Body: "INSERT INTO AccountLog (Account, Message) VALUES (NEW.Id, 'changed; see $$ history')",
+		},
+	}
+
+	if len(actualResult.Triggers) != len(expectedTriggers) {
+		t.Fatalf("Expected %v triggers, but got %v", len(expectedTriggers), len(actualResult.Triggers))
+	}
+
+	for i := 0; i < len(expectedTriggers); i++ {
+		internal.DiffCompare(t, actualResult.Triggers[i], expectedTriggers[i], fmt.Sprintf("trigger %s", expectedTriggers[i].Name))
+	}
+
+	money := ddl.DataType{Name: "DECIMAL", Precision: i(10), Scale: i(2)}
+	expectedRoutines := []ddl.Routine{
+		{
+			Kind: ddl.RoutineProcedure,
+			Name: "Transfer",
+			Parameters: []ddl.RoutineParameter{
+				{Direction: ddl.ParameterIn, Name: "source", Type: ddl.DataType{Name: "INT"}},
+				{Direction: ddl.ParameterIn, Name: "target", Type: ddl.DataType{Name: "INT"}},
+				{Name: "amount", Type: money},
+				{Direction: ddl.ParameterOut, Name: "success", Type: ddl.DataType{Name: "BOOL"}},
+			},
+			Comment:    s("moves money"),
+			DataAccess: ddl.DataModifiesSQL,
+			Body: "BEGIN\n" +
+				"    UPDATE Account SET Balance = Balance - amount WHERE Id = source;\n" +
+				"    UPDATE Account SET Balance = Balance + amount WHERE Id = target;\n" +
+				"    SET success = TRUE;\n" +
+				"END",
+		},
+		{
+			Kind: ddl.RoutineProcedure,
+			Name: "Archive",
+			Body: "BEGIN\n" +
+				"    CREATE TEMPORARY TABLE ArchivedLog (Account INT NOT NULL, Message VARCHAR(255) NOT NULL);\n" +
+				"    INSERT INTO ArchivedLog SELECT * FROM AccountLog;\n" +
+				"    ALTER TABLE AccountLog ADD COLUMN Archived DATETIME;\n" +
+				"    CREATE INDEX Archived ON AccountLog (Archived);\n" +
+				"    TRUNCATE TABLE AccountLog;\n" +
+				"    RENAME TABLE ArchivedLog TO OldLog;\n" +
+				"    DROP TABLE OldLog;\n" +
+				"END",
+		},
+		{
+			Kind:          ddl.RoutineFunction,
+			Name:          "Tax",
+			Parameters:    []ddl.RoutineParameter{{Name: "amount", Type: money}},
+			Returns:       &money,
+			Deterministic: true,
+			DataAccess:    ddl.DataNoSQL,
+			Security:      ddl.SecurityInvoker,
+			Body:          "RETURN amount * 0.19",
+		},
+		{
+			Kind:       ddl.RoutineFunction,
+			Name:       "Total",
+			Parameters: []ddl.RoutineParameter{{Name: "account", Type: ddl.DataType{Name: "INT"}}},
+			Returns:    &money,
+			DataAccess: ddl.DataReadsSQL,
+			Body: "BEGIN\n" +
+				"    DECLARE total DECIMAL(10, 2);\n" +
+				"    SELECT Balance INTO total FROM Account WHERE Id = account;\n" +
+				"    RETURN total;\n" +
+				"END",
+		},
+	}
+
+	if len(actualResult.Routines) != len(expectedRoutines) {
+		t.Fatalf("Expected %v routines, but got %v", len(expectedRoutines), len(actualResult.Routines))
+	}
+
+	for i := 0; i < len(expectedRoutines); i++ {
+		internal.DiffCompare(t, actualResult.Routines[i], expectedRoutines[i], fmt.Sprintf("routine %s", expectedRoutines[i].Name))
+	}
+}
+
+func TestParseSchemas(t *testing.T) {
+	sql := loadSql("schemas.sql")
+
+	actualResult, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDatabases := []ddl.Database{
+		{Name: "shop", IfNotExists: true, CharacterSet: s("utf8mb4")},
+		{Name: "crm", Collate: s("utf8mb4_bin")},
+	}
+
+	internal.DiffCompare(t, actualResult.Databases, expectedDatabases, "databases")
+
+	expectedTables := []ddl.Table{
+		{
+			Name: "Setting",
+			Columns: []ddl.Column{
+				{Name: "Name", Type: ddl.DataType{Name: "VARCHAR", Length: i(64)}, NotNull: true, PrimaryKey: true},
+			},
+		},
+		{
+			Schema: "crm",
+			Name:   "Customer",
+			Columns: []ddl.Column{
+				{Name: "Id", Type: ddl.DataType{Name: "INT"}, NotNull: true, PrimaryKey: true},
+			},
+		},
+		{
+			Schema: "shop",
+			Name:   "Order",
+			Columns: []ddl.Column{
+				{Name: "Id", Type: ddl.DataType{Name: "INT"}, NotNull: true, PrimaryKey: true},
+				{Name: "Customer", Type: ddl.DataType{Name: "INT"}, NotNull: true},
+				{Name: "Product", Type: ddl.DataType{Name: "INT"}, NotNull: true},
+			},
+			ForeignKeys: []ddl.ForeignKeyConstraint{
+				{
+					Columns:          []string{"Customer"},
+					ReferenceSchema:  "crm",
+					ReferenceTable:   "Customer",
+					ReferenceColumns: []string{"Id"},
+				},
+				{
+					Columns:          []string{"Product"},
+					ReferenceSchema:  "shop",
+					ReferenceTable:   "Product",
+					ReferenceColumns: []string{"Id"},
+				},
+			},
+		},
+		{
+			Schema: "shop",
+			Name:   "Product",
+			Columns: []ddl.Column{
+				{Name: "Id", Type: ddl.DataType{Name: "INT"}, NotNull: true, PrimaryKey: true},
+			},
+		},
+	}
+
+	if len(actualResult.Tables) != len(expectedTables) {
+		t.Fatalf("Expected %v tables, but got %v", len(expectedTables), len(actualResult.Tables))
+	}
+
+	for i := 0; i < len(expectedTables); i++ {
+		internal.DiffCompare(t, actualResult.Tables[i], expectedTables[i], fmt.Sprintf("table %s", expectedTables[i].Name))
+	}
+
+	expectedViews := []ddl.View{
+		{
+			Schema:       "shop",
+			Name:         "CustomerOrders",
+			Definition:   "SELECT c.Id, o.Id AS OrderId FROM `Order` o JOIN crm.Customer c ON c.Id = o.Customer",
+			Dependencies: []string{"Order", "crm.Customer"},
+		},
+	}
+
+	internal.DiffCompare(t, actualResult.Views, expectedViews, "views")
+
+	expectedAlterStatements := []ddl.AlterStatement{
+		ddl.AlterAddColumn{
+			Schema: "crm",
+			Table:  "Customer",
+			Column: ddl.Column{Name: "Email", Type: ddl.DataType{Name: "VARCHAR", Length: i(255)}},
+		},
+		ddl.AlterAddIndex{
+			Schema: "shop",
+			Table:  "Order",
+			Name:   "IdxProduct",
+			Parts:  []ddl.IndexPart{{Column: "Product"}},
+		},
+		ddl.AlterDropIndex{
+			Schema: "shop",
+			Table:  "Order",
+			Index:  "IdxProduct",
+		},
+	}
+
+	internal.DiffCompare(t, actualResult.AlterStatements, expectedAlterStatements, "alter statements")
+}
+
+func TestParsePartitions(t *testing.T) {
+	sql := loadSql("partitions.sql")
+
+	actualResult, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPartitionings := map[string]*ddl.Partitioning{
+		"Event": {
+			Function: ddl.PartitionFunction{Method: ddl.PartitionRange, Expression: "YEAR(Created)"},
+			Partitions: []ddl.Partition{
+				{Name: "p2020", LessThan: []string{"2021"}, Options: ddl.PartitionOptions{Comment: s("archived")}},
+				{Name: "p2021", LessThan: []string{"2022"}, Options: ddl.PartitionOptions{Engine: s("InnoDB")}},
+				{Name: "pMax", LessThan: []string{"MAXVALUE"}},
+			},
+		},
+		"Region": {
+			Function: ddl.PartitionFunction{Method: ddl.PartitionList, Columns: []string{"Country", "City"}},
+			Partitions: []ddl.Partition{
+				{Name: "pEU", In: []string{"('DE', 'Berlin')", "('FR', 'Paris')"}},
+				{Name: "pUS", In: []string{"('US', 'Boston')"}},
+			},
+		},
+		"Tag": {
+			Function: ddl.PartitionFunction{Method: ddl.PartitionList, Expression: "Kind"},
+			Partitions: []ddl.Partition{
+				{Name: "a", In: []string{"1", "2"}},
+				{Name: "b", In: []string{"3"}},
+			},
+		},
+		"Session": {
+			Function: ddl.PartitionFunction{Method: ddl.PartitionKey, Linear: true, Columns: []string{"Id"}, Algorithm: i(2)},
+			Count:    i(4),
+		},
+		"Log": {
+			Function:          ddl.PartitionFunction{Method: ddl.PartitionRange, Columns: []string{"Id"}},
+			Subpartitioning:   &ddl.PartitionFunction{Method: ddl.PartitionKey, Columns: []string{"Id"}},
+			SubpartitionCount: i(2),
+			Partitions: []ddl.Partition{
+				{Name: "p0", LessThan: []string{"100"}},
+				{Name: "p1", LessThan: []string{"MAXVALUE"}},
+			},
+		},
+	}
+
+	if len(actualResult.Tables) != len(expectedPartitionings) {
+		t.Fatalf("Expected %v tables, but got %v", len(expectedPartitionings), len(actualResult.Tables))
+	}
+
+	for _, table := range actualResult.Tables {
+		internal.DiffCompare(t, table.Partitioning, expectedPartitionings[table.Name], fmt.Sprintf("table %s", table.Name))
+	}
+}
+
+func TestParseSubpartitions(t *testing.T) {
+	// Explicit subpartitions are only understood without parentheses around them.
+	sql := `CREATE TABLE Measurement (Taken DATE NOT NULL)
+		PARTITION BY RANGE (YEAR(Taken)) SUBPARTITION BY HASH (TO_DAYS(Taken)) (
+			PARTITION p0 VALUES LESS THAN (2000) SUBPARTITION s0, SUBPARTITION s1 MAX_ROWS = 1000,
+			PARTITION p1 VALUES LESS THAN MAXVALUE SUBPARTITION s2, SUBPARTITION s3
+		);`
+
+	actualResult, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPartitions := []ddl.Partition{
+		{
+			Name:     "p0",
+			LessThan: []string{"2000"},
+			Subpartitions: []ddl.Subpartition{
+				{Name: "s0"},
+				{Name: "s1", Options: ddl.PartitionOptions{MaxRows: i(1000)}},
+			},
+		},
+		{
+			Name:          "p1",
+			LessThan:      []string{"MAXVALUE"},
+			Subpartitions: []ddl.Subpartition{{Name: "s2"}, {Name: "s3"}},
+		},
+	}
+
+	internal.DiffCompare(t, actualResult.Tables[0].Partitioning.Partitions, expectedPartitions, "partitions")
+}
+
+func TestParseIndexes(t *testing.T) {
+	sql := loadSql("indexes.sql")
+
+	actualResult, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actualResult.Tables) != 1 {
+		t.Fatalf("Expected 1 table, but got %v", len(actualResult.Tables))
+	}
+
+	table := actualResult.Tables[0]
+
+	expectedPrimaryKey := &ddl.PrimaryKeyConstraint{
+		Parts:   []ddl.IndexPart{{Column: "Id"}},
+		Options: ddl.IndexOptions{Type: ddl.IndexBTree},
+	}
+
+	internal.DiffCompare(t, table.PrimaryKey, expectedPrimaryKey, "primary key")
+
+	expectedUniques := []ddl.UniqueConstraint{
+		{
+			Name:    s("UniqueSlug"),
+			Parts:   []ddl.IndexPart{{Column: "Slug"}},
+			Options: ddl.IndexOptions{Type: ddl.IndexHash, Comment: s("lookup")},
+		},
+	}
+
+	internal.DiffCompare(t, table.UniqueConstraints, expectedUniques, "unique constraints")
+
+	expectedKeys := []ddl.Key{
+		{
+			Name:    s("IdxTitle"),
+			Parts:   []ddl.IndexPart{{Column: "Title", Length: 32}},
+			Options: ddl.IndexOptions{Type: ddl.IndexBTree, KeyBlockSize: s("8"), Invisible: true},
+		},
+		{
+			Name:    s("FtBody"),
+			Kind:    ddl.IndexFulltext,
+			Parts:   []ddl.IndexPart{{Column: "Title"}, {Column: "Body"}},
+			Options: ddl.IndexOptions{Parser: s("ngram")},
+		},
+		{
+			Kind:  ddl.IndexSpatial,
+			Parts: []ddl.IndexPart{{Column: "Location"}},
+		},
+	}
+
+	internal.DiffCompare(t, table.Keys, expectedKeys, "keys")
+
+	expectedAlterStatements := []ddl.AlterStatement{
+		ddl.AlterAddIndex{
+			Table:   "Article",
+			Name:    "FtTitle",
+			Parts:   []ddl.IndexPart{{Column: "Title"}},
+			Kind:    ddl.IndexFulltext,
+			Options: ddl.IndexOptions{Comment: s("search")},
+		},
+		ddl.AlterAddIndex{
+			Table:  "Article",
+			Name:   "UniqueTitle",
+			Parts:  []ddl.IndexPart{{Column: "Title", Length: 100}},
+			Unique: true,
+		},
+		ddl.AlterAddIndex{
+			Table:   "Article",
+			Parts:   []ddl.IndexPart{{Column: "Slug"}, {Column: "Title"}},
+			Options: ddl.IndexOptions{Type: ddl.IndexHash},
+		},
+		ddl.AlterAddIndex{
+			Table: "Article",
+			Name:  "SpLocation",
+			Parts: []ddl.IndexPart{{Column: "Location"}},
+			Kind:  ddl.IndexSpatial,
+		},
+	}
+
+	internal.DiffCompare(t, actualResult.AlterStatements, expectedAlterStatements, "alter statements")
+}
+
+func TestParseAlterColumns(t *testing.T) {
+	sql := loadSql("alter-columns.sql")
+
+	actualResult, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedAlterStatements := []ddl.AlterStatement{
+		ddl.AlterModifyColumn{
+			Table:  "User",
+			Column: ddl.Column{Name: "Age", Type: ddl.DataType{Name: "BIGINT", Unsigned: true}, NotNull: true, Invisible: true},
+		},
+		ddl.AlterModifyColumn{
+			Table:  "User",
+			Column: ddl.Column{Name: "Name", Type: ddl.DataType{Name: "VARCHAR", Length: i(100)}},
+			After:  s("Id"),
+		},
+		ddl.AlterChangeColumn{
+			Table:   "User",
+			OldName: "Mail",
+			Column:  ddl.Column{Name: "Email", Type: ddl.DataType{Name: "VARCHAR", Length: i(255)}, NotNull: true},
+			First:   true,
+		},
+		ddl.AlterChangeColumn{
+			Table:   "User",
+			OldName: "Email",
+			Column:  ddl.Column{Name: "Email", Type: ddl.DataType{Name: "VARCHAR", Length: i(320)}},
+			After:   s("Name"),
+		},
+		ddl.AlterRenameColumn{
+			Table:   "User",
+			OldName: "Name",
+			NewName: "FullName",
+		},
+	}
+
+	if len(actualResult.AlterStatements) != len(expectedAlterStatements) {
+		t.Fatalf("Expected %v statements, but got %v", len(expectedAlterStatements), len(actualResult.AlterStatements))
+	}
+
+	for i := 0; i < len(expectedAlterStatements); i++ {
+		internal.DiffCompare(t, actualResult.AlterStatements[i], expectedAlterStatements[i], fmt.Sprintf("statement #%d", i))
+	}
+}
+
+func TestParseAlterOptions(t *testing.T) {
+	sql := loadSql("alter-options.sql")
+
+	actualResult, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedAlterStatements := []ddl.AlterStatement{
+		ddl.AlterTableOptions{
+			Table: "Product",
+			Options: ddl.TableOptions{
+				Engine:  s("MyISAM"),
+				Comment: s("All products"),
+				Others:  []string{"STATS_PERSISTENT=1"},
+			},
+		},
+		ddl.AlterTableOptions{
+			Schema: "shop",
+			Table:  "Product",
+			Options: ddl.TableOptions{
+				AutoIncrement: s("100"),
+				CharacterSet:  s("utf8mb4"),
+				Collate:       s("utf8mb4_bin"),
+				RowFormat:     s("COMPACT"),
+			},
+		},
+	}
+
+	if len(actualResult.AlterStatements) != len(expectedAlterStatements) {
+		t.Fatalf("Expected %v statements, but got %v", len(expectedAlterStatements), len(actualResult.AlterStatements))
+	}
+
+	for i := 0; i < len(expectedAlterStatements); i++ {
+		internal.DiffCompare(t, actualResult.AlterStatements[i], expectedAlterStatements[i], fmt.Sprintf("statement #%d", i))
+	}
+}
+
+func TestParseAlterConstraints(t *testing.T) {
+	sql := loadSql("alter-constraints.sql")
+
+	actualResult, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedAlterStatements := []ddl.AlterStatement{
+		ddl.AlterAddForeignKey{
+			Table: "Order",
+			ForeignKey: ddl.ForeignKeyConstraint{
+				Name:             s("OrderCustomer"),
+				Columns:          []string{"CustomerId"},
+				ReferenceTable:   "Customer",
+				ReferenceColumns: []string{"Id"},
+				OnDelete:         ddl.ReferenceCascade,
+			},
+		},
+		ddl.AlterAddForeignKey{
+			Table: "Order",
+			ForeignKey: ddl.ForeignKeyConstraint{
+				Columns:          []string{"ShopId", "ShopRegion"},
+				ReferenceSchema:  "crm",
+				ReferenceTable:   "Shop",
+				ReferenceColumns: []string{"Id", "Region"},
+			},
+		},
+		ddl.AlterDropForeignKey{Table: "Order", Name: "OrderCustomer"},
+		ddl.AlterDropPrimaryKey{Table: "Order"},
+		ddl.AlterAddPrimaryKey{
+			Table: "Order",
+			PrimaryKey: ddl.PrimaryKeyConstraint{
+				Parts:   []ddl.IndexPart{{Column: "Id"}, {Column: "CustomerId"}},
+				Options: ddl.IndexOptions{Type: ddl.IndexBTree},
+			},
+		},
+		ddl.AlterAddIndex{
+			Table:  "Order",
+			Name:   "OrderNumber",
+			Parts:  []ddl.IndexPart{{Column: "Number"}},
+			Unique: true,
+		},
+		ddl.AlterAddIndex{
+			Table:  "Order",
+			Parts:  []ddl.IndexPart{{Column: "Reference"}},
+			Unique: true,
+		},
+		ddl.AlterAddCheck{
+			Table: "Order",
+			Check: ddl.CheckConstraint{Name: s("PositiveTotal"), Expression: "Total >= 0", NotEnforced: true},
+		},
+		ddl.AlterDropConstraint{Table: "Order", Name: "PositiveTotal", Check: true},
+		ddl.AlterDropConstraint{Table: "Order", Name: "OrderNumber"},
+	}
+
+	if len(actualResult.AlterStatements) != len(expectedAlterStatements) {
+		t.Fatalf("Expected %v statements, but got %v", len(expectedAlterStatements), len(actualResult.AlterStatements))
+	}
+
+	for i := 0; i < len(expectedAlterStatements); i++ {
+		internal.DiffCompare(t, actualResult.AlterStatements[i], expectedAlterStatements[i], fmt.Sprintf("statement #%d", i))
+	}
+}
+
+func TestParseSchemaStatements(t *testing.T) {
+	sql := loadSql("schema-statements.sql")
+
+	actualResult, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSchemaStatements := []ddl.SchemaStatement{
+		ddl.RenameTable{Schema: "shop", Table: "Customer", NewSchema: "shop", NewTable: "Client"},
+		ddl.RenameTable{Schema: "crm", Table: "Contact", NewSchema: "crm", NewTable: "Person"},
+		ddl.RenameTable{Schema: "crm", Table: "Lead", NewSchema: "shop", NewTable: "Prospect"},
+		ddl.RenameTable{Schema: "shop", Table: "Order", NewSchema: "shop", NewTable: "Purchase"},
+		ddl.RenameTable{Schema: "crm", Table: "Note", NewSchema: "crm", NewTable: "Remark"},
+		ddl.DropTable{Schema: "shop", Table: "Cart", IfExists: true},
+		ddl.DropTable{Schema: "crm", Table: "Basket", IfExists: true},
+		ddl.DropTable{Schema: "shop", Table: "Wishlist"},
+		ddl.TruncateTable{Schema: "shop", Table: "Session"},
+		ddl.TruncateTable{Schema: "crm", Table: "Log"},
+	}
+
+	internal.DiffCompare(t, actualResult.SchemaStatements, expectedSchemaStatements, "schema statements")
+}
+
 // Returns a list of ALTER TABLE statements, that should be present in testdata/alter-user.sql.
 func expectedUserAlterStatements() []ddl.AlterStatement {
 	return []ddl.AlterStatement{
 		ddl.AlterAddColumn{
 			Table:  "User",
-			Column: ddl.Column{Name: "BirthDate", Type: "DATE"},
+			Column: ddl.Column{Name: "BirthDate", Type: ddl.DataType{Name: "DATE"}},
 		},
 		ddl.AlterAddColumn{
 			Table:  "User",
-			Column: ddl.Column{Name: "Comment", Type: "TEXT"},
+			Column: ddl.Column{Name: "Comment", Type: ddl.DataType{Name: "TEXT"}, Invisible: true},
 		},
 		ddl.AlterAddColumn{
 			Table:  "User",
-			Column: ddl.Column{Name: "BirthYear", Type: "INT"},
+			Column: ddl.Column{Name: "BirthYear", Type: ddl.DataType{Name: "INT"}},
 		},
 		ddl.AlterAddColumn{
 			Table:  "User",
-			Column: ddl.Column{Name: "Id", Type: "INT", NotNull: true, Default: s("123")},
+			Column: ddl.Column{Name: "Id", Type: ddl.DataType{Name: "INT"}, NotNull: true, Default: s("123")},
 			First:  true,
 		},
 		ddl.AlterAddColumn{
 			Table:  "User",
-			Column: ddl.Column{Name: "Id", Type: "INT", NotNull: true, Default: s("123")},
+			Column: ddl.Column{Name: "Id", Type: ddl.DataType{Name: "INT"}, NotNull: true, Default: s("123")},
 			After:  s("BirthDate"),
 		},
 		ddl.AlterDropColumn{
@@ -113,7 +754,7 @@ func expectedUserAlterStatements() []ddl.AlterStatement {
 		ddl.AlterAddIndex{
 			Table:  "User",
 			Name:   "IndexId",
-			Column: "Id",
+			Parts:  []ddl.IndexPart{{Column: "Id"}},
 			Unique: true,
 		},
 		ddl.AlterDropIndex{
@@ -124,6 +765,11 @@ func expectedUserAlterStatements() []ddl.AlterStatement {
 			Table: "User",
 			Index: "IndexId2",
 		},
+		ddl.AlterAddIndex{
+			Table: "User",
+			Name:  "IndexName",
+			Parts: []ddl.IndexPart{{Column: "LastName", Length: 10}, {Column: "FirstName", Descending: true}},
+		},
 	}
 }
 
@@ -134,51 +780,194 @@ func expectedMusicTables() []ddl.Table {
 			Name:        "Artist",
 			IfNotExists: true,
 			Columns: []ddl.Column{
-				{Name: "Id", Type: "INT", PrimaryKey: true},
-				{Name: "Name", Type: "VARCHAR(255)", NotNull: true, Unique: true},
-				{Name: "BirthYear", Type: "INT", NotNull: true},
+				{Name: "Id", Type: ddl.DataType{Name: "INT"}, PrimaryKey: true},
+				{Name: "Name", Type: ddl.DataType{Name: "VARCHAR", Length: i(255)}, NotNull: true, Unique: true},
+				{Name: "BirthYear", Type: ddl.DataType{Name: "INT"}, NotNull: true},
 			},
 		},
 		{
 			Name: "Song",
 			Columns: []ddl.Column{
-				{Name: "Id", Type: "INT", PrimaryKey: true},
-				{Name: "Name", Type: "VARCHAR(255)", NotNull: true},
-				{Name: "Album", Type: "INT"},
+				{Name: "Id", Type: ddl.DataType{Name: "INT"}, PrimaryKey: true},
+				{Name: "Name", Type: ddl.DataType{Name: "VARCHAR", Length: i(255)}, NotNull: true},
+				{Name: "Album", Type: ddl.DataType{Name: "INT"}},
 			},
 			ForeignKeys: []ddl.ForeignKeyConstraint{
-				{Column: "Album", ReferenceTable: "Album", ReferenceColumn: "Id"},
+				{Columns: []string{"Album"}, ReferenceTable: "Album", ReferenceColumns: []string{"Id"}},
 			},
 		},
 		{
 			Name: "WorkedOn",
 			Columns: []ddl.Column{
-				{Name: "Artist", Type: "INT", NotNull: true},
-				{Name: "Song", Type: "INT", NotNull: true},
+				{Name: "Artist", Type: ddl.DataType{Name: "INT"}, NotNull: true},
+				{Name: "Song", Type: ddl.DataType{Name: "INT"}, NotNull: true},
 			},
 			ForeignKeys: []ddl.ForeignKeyConstraint{
-				{Name: s("Wrote"), Column: "Artist", ReferenceTable: "Artist", ReferenceColumn: "Id"},
-				{Name: s("WrittenBy"), Column: "Song", ReferenceTable: "Song", ReferenceColumn: "Id"},
+				{Name: s("Wrote"), Columns: []string{"Artist"}, ReferenceTable: "Artist", ReferenceColumns: []string{"Id"}},
+				{Name: s("WrittenBy"), Columns: []string{"Song"}, ReferenceTable: "Song", ReferenceColumns: []string{"Id"}},
 			},
 		},
 		{
 			Name: "Album",
 			Columns: []ddl.Column{
-				{Name: "Id", Type: "INT", PrimaryKey: true},
-				{Name: "Name", Type: "VARCHAR(255)"},
-				{Name: "Year", Type: "INT", Default: s("2000")},
+				{Name: "Id", Type: ddl.DataType{Name: "INT"}, PrimaryKey: true},
+				{Name: "Name", Type: ddl.DataType{Name: "VARCHAR", Length: i(255)}},
+				{Name: "Year", Type: ddl.DataType{Name: "INT"}, Default: s("2000")},
 			},
 		},
 		{
 			Name: "Publisher",
 			Columns: []ddl.Column{
-				{Name: "Id", Type: "INT", PrimaryKey: true},
-				{Name: "Uuid", Type: "INT"},
-				{Name: "Year", Type: "INT"},
+				{Name: "Id", Type: ddl.DataType{Name: "INT"}, PrimaryKey: true},
+				{Name: "Uuid", Type: ddl.DataType{Name: "INT"}},
+				{Name: "Year", Type: ddl.DataType{Name: "INT"}},
+			},
+			Keys: []ddl.Key{
+				{Name: s("k_uuid"), Parts: []ddl.IndexPart{{Column: "Uuid"}}},
+				{Parts: []ddl.IndexPart{{Column: "Year"}}},
+			},
+		},
+	}
+}
+
+// expectedCompositeTables returns the expected model from the testdata/composite.sql example.
+func expectedCompositeTables() []ddl.Table {
+	return []ddl.Table{
+		{
+			Name: "Order",
+			Columns: []ddl.Column{
+				{Name: "TenantId", Type: ddl.DataType{Name: "INT"}, NotNull: true},
+				{Name: "Id", Type: ddl.DataType{Name: "INT"}, NotNull: true},
+			},
+			PrimaryKey: &ddl.PrimaryKeyConstraint{
+				Parts: []ddl.IndexPart{{Column: "TenantId"}, {Column: "Id"}},
+			},
+		},
+		{
+			Name: "OrderItem",
+			Columns: []ddl.Column{
+				{Name: "TenantId", Type: ddl.DataType{Name: "INT"}, NotNull: true},
+				{Name: "OrderId", Type: ddl.DataType{Name: "INT"}, NotNull: true},
+				{Name: "Position", Type: ddl.DataType{Name: "INT"}, NotNull: true},
+				{Name: "Note", Type: ddl.DataType{Name: "VARCHAR", Length: i(255)}},
+				{Name: "ReplacedBy", Type: ddl.DataType{Name: "INT"}},
+			},
+			PrimaryKey: &ddl.PrimaryKeyConstraint{
+				Name:  s("PkOrderItem"),
+				Parts: []ddl.IndexPart{{Column: "TenantId"}, {Column: "OrderId"}, {Column: "Position"}},
+			},
+			UniqueConstraints: []ddl.UniqueConstraint{
+				{Name: s("UqNote"), Parts: []ddl.IndexPart{{Column: "TenantId"}, {Column: "Note", Length: 50}}},
+				{Name: s("UqReplacedBy"), Parts: []ddl.IndexPart{{Column: "ReplacedBy"}}},
+			},
+			ForeignKeys: []ddl.ForeignKeyConstraint{
+				{
+					Name:             s("FkOrder"),
+					Columns:          []string{"TenantId", "OrderId"},
+					ReferenceTable:   "Order",
+					ReferenceColumns: []string{"TenantId", "Id"},
+					OnDelete:         ddl.ReferenceCascade,
+					OnUpdate:         ddl.ReferenceRestrict,
+				},
+				{
+					Columns:          []string{"ReplacedBy"},
+					ReferenceTable:   "Order",
+					ReferenceColumns: []string{"Id"},
+					Match:            ddl.MatchSimple,
+					OnDelete:         ddl.ReferenceSetNull,
+					OnUpdate:         ddl.ReferenceNoAction,
+				},
 			},
 			Keys: []ddl.Key{
-				{Name: s("k_uuid"), OnColumn: "Uuid"},
-				{OnColumn: "Year"},
+				{
+					Name: s("IdxPosition"),
+					Parts: []ddl.IndexPart{
+						{Column: "TenantId"},
+						{Column: "Position", Descending: true},
+						{Column: "Note", Length: 20},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expectedShopTables returns the expected model from the testdata/shop.sql example.
+func expectedShopTables() []ddl.Table {
+	return []ddl.Table{
+		{
+			Name: "Product",
+			Columns: []ddl.Column{
+				{Name: "Id", Type: ddl.DataType{Name: "INT"}, NotNull: true},
+				{Name: "Name", Type: ddl.DataType{Name: "VARCHAR", Length: i(255)}, NotNull: true},
+				{
+					Name: "Price", Type: ddl.DataType{Name: "INT"}, NotNull: true,
+					Checks: []ddl.CheckConstraint{{Expression: "Price > 0"}},
+				},
+				{
+					Name: "Discount", Type: ddl.DataType{Name: "INT"},
+					Checks: []ddl.CheckConstraint{{Name: s("DiscountRange"), Expression: "Discount BETWEEN 0 AND 100"}},
+				},
+				{
+					Name: "Total", Type: ddl.DataType{Name: "INT"},
+					Generated: &ddl.GeneratedColumn{Expression: "Price - Discount", Storage: ddl.GeneratedVirtual},
+				},
+				{
+					Name: "Label", Type: ddl.DataType{Name: "VARCHAR", Length: i(300)}, NotNull: true,
+					Generated: &ddl.GeneratedColumn{Expression: "CONCAT(Name, ' ', Price)", Storage: ddl.GeneratedStored},
+				},
+			},
+			Checks: []ddl.CheckConstraint{
+				{Name: s("DiscountBelowPrice"), Expression: "Discount < Price", NotEnforced: true},
+				{Expression: "LENGTH(Name) > 2"},
+			},
+			Options: ddl.TableOptions{
+				Engine:        s("InnoDB"),
+				AutoIncrement: s("1234"),
+				CharacterSet:  s("utf8mb4"),
+				Collate:       s("utf8mb4_unicode_ci"),
+				Comment:       s("All products"),
+				RowFormat:     s("DYNAMIC"),
+				Others:        []string{"STATS_PERSISTENT=0"},
+			},
+		},
+		{
+			Name: "Customer",
+			Columns: []ddl.Column{
+				{Name: "Id", Type: ddl.DataType{Name: "INT"}, NotNull: true, AutoIncrement: true, Comment: s("The customer's id")},
+				{
+					Name: "Name", NotNull: true,
+					Type: ddl.DataType{Name: "VARCHAR", Length: i(255), CharacterSet: s("utf8mb4"), Collate: s("utf8mb4_bin")},
+				},
+				{Name: "Note", Type: ddl.DataType{Name: "TEXT", Collate: s("utf8mb4_unicode_ci")}, Null: true, Invisible: true},
+				{Name: "Created", Type: ddl.DataType{Name: "TIMESTAMP"}, Default: s("CURRENT_TIMESTAMP")},
+				{
+					Name: "Updated", Type: ddl.DataType{Name: "TIMESTAMP"}, Null: true,
+					Default: s("CURRENT_TIMESTAMP"), OnUpdate: s("CURRENT_TIMESTAMP"),
+				},
+				{Name: "Touched", Type: ddl.DataType{Name: "DATETIME"}, OnUpdate: s("NOW()")},
+			},
+		},
+		{
+			Name: "Measurement",
+			Columns: []ddl.Column{
+				{Name: "Amount", Type: ddl.DataType{Name: "DECIMAL", Precision: i(10), Scale: i(2), Unsigned: true, Zerofill: true}},
+				{Name: "Ratio", Type: ddl.DataType{Name: "DOUBLE PRECISION"}},
+				{Name: "Counter", Type: ddl.DataType{Name: "BIGINT", Length: i(20), Unsigned: true}, NotNull: true},
+				{Name: "Code", Type: ddl.DataType{Name: "CHAR", Length: i(3), Binary: true}},
+				{Name: "Hash", Type: ddl.DataType{Name: "BINARY", Length: i(16)}},
+				{Name: "Payload", Type: ddl.DataType{Name: "LONG VARBINARY"}},
+				{Name: "Title", Type: ddl.DataType{Name: "NATIONAL VARCHAR", Length: i(100)}},
+				{Name: "Weight", Type: ddl.DataType{Name: "FLOAT", Precision: i(7), Scale: i(4)}},
+				{Name: "Exact", Type: ddl.DataType{Name: "DECIMAL", Precision: i(8)}},
+				{Name: "At", Type: ddl.DataType{Name: "DATETIME", Length: i(6)}},
+				{Name: "Mood", Type: ddl.DataType{Name: "ENUM", Values: []string{"happy", "sad"}, CharacterSet: s("latin1")}},
+				{
+					Name: "Tags", NotNull: true, Default: s("'new'"),
+					Type: ddl.DataType{Name: "SET", Values: []string{"new", "sale, limited", `it's "good"`}},
+				},
+				{Name: "Status", Type: ddl.DataType{Name: "ENUM", Values: []string{"draft", "published"}, Binary: true}},
+				{Name: "Visible", Type: ddl.DataType{Name: "BOOLEAN"}, Invisible: true},
 			},
 		},
 	}
@@ -188,3 +977,8 @@ func expectedMusicTables() []ddl.Table {
 func s(s string) *string {
 	return &s
 }
+
+// Turn an int into an *int. Needed for optional lengths.
+func i(i int) *int {
+	return &i
+}
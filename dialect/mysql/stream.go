@@ -0,0 +1,36 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"unicode"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// upperCaseStream presents all characters as upper case to the lexer, because the grammar
+// only declares upper case keywords. The text of the tokens keeps its original case.
+type upperCaseStream struct {
+	antlr.CharStream
+}
+
+func (s upperCaseStream) LA(offset int) int {
+	c := s.CharStream.LA(offset)
+	if c <= 0 {
+		return c
+	}
+
+	return int(unicode.ToUpper(rune(c)))
+}
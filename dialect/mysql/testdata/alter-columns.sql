@@ -0,0 +1,5 @@
+ALTER TABLE User MODIFY Age BIGINT UNSIGNED INVISIBLE NOT NULL;
+ALTER TABLE User MODIFY COLUMN Name VARCHAR(100) AFTER Id;
+ALTER TABLE User CHANGE Mail Email VARCHAR(255) NOT NULL FIRST;
+ALTER TABLE User CHANGE COLUMN Email Email VARCHAR(320) VISIBLE AFTER `Name`;
+ALTER TABLE `User` RENAME COLUMN Name TO FullName;
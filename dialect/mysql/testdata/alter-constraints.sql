@@ -0,0 +1,7 @@
+ALTER TABLE `Order` ADD CONSTRAINT `OrderCustomer` FOREIGN KEY (CustomerId) REFERENCES Customer (Id) ON DELETE CASCADE;
+ALTER TABLE `Order` ADD FOREIGN KEY (ShopId, ShopRegion) REFERENCES crm.Shop (Id, Region);
+ALTER TABLE `Order` DROP FOREIGN KEY OrderCustomer;
+ALTER TABLE `Order` DROP PRIMARY KEY, ADD PRIMARY KEY (Id, CustomerId) USING BTREE;
+ALTER TABLE `Order` ADD CONSTRAINT UNIQUE KEY OrderNumber (Number), ADD UNIQUE (Reference);
+ALTER TABLE `Order` ADD CONSTRAINT PositiveTotal CHECK (Total >= 0) NOT ENFORCED;
+ALTER TABLE `Order` DROP CHECK PositiveTotal, DROP CONSTRAINT OrderNumber;
@@ -0,0 +1,2 @@
+ALTER TABLE Product ENGINE=MyISAM COMMENT='All products', STATS_PERSISTENT=1;
+ALTER TABLE shop.Product DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_bin ROW_FORMAT=COMPACT AUTO_INCREMENT=100;
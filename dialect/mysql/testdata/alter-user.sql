@@ -1,4 +1,4 @@
-ALTER TABLE `User` ADD COLUMN BirthDate DATE, ADD COLUMN Comment TEXT;
+ALTER TABLE `User` ADD COLUMN BirthDate DATE, ADD COLUMN Comment TEXT INVISIBLE;
 ALTER TABLE User ADD `BirthYear` INT;
 ALTER TABLE User ADD "Id" INT NOT NULL DEFAULT 123 FIRST;
 ALTER TABLE "User" ADD Id INT NOT NULL DEFAULT 123 AFTER 'BirthDate';
@@ -6,3 +6,4 @@ ALTER TABLE User DROP `BirthDate`, DROP COLUMN Id;
 CREATE UNIQUE INDEX `IndexId` ON `User` (`Id`);
 DROP INDEX `IndexId` ON `User`;
 ALTER TABLE `User` DROP INDEX IndexId2;
+CREATE INDEX `IndexName` ON `User` (LastName(10), FirstName DESC);
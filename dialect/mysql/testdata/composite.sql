@@ -0,0 +1,22 @@
+-- Tables that use composite keys and indexes.
+
+CREATE TABLE `Order` (
+    TenantId INT NOT NULL,
+    Id INT NOT NULL,
+    PRIMARY KEY (TenantId, Id)
+);
+
+CREATE TABLE OrderItem (
+    TenantId INT NOT NULL,
+    OrderId INT NOT NULL,
+    Position INT NOT NULL,
+    Note VARCHAR(255),
+    ReplacedBy INT,
+    CONSTRAINT FkOrder FOREIGN KEY (TenantId, `OrderId`) REFERENCES `Order` (TenantId, Id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+    FOREIGN KEY (ReplacedBy) REFERENCES `Order` (Id) MATCH SIMPLE ON DELETE SET NULL ON UPDATE NO ACTION,
+    CONSTRAINT PkOrderItem PRIMARY KEY (TenantId, OrderId, Position),
+    CONSTRAINT UqNote UNIQUE KEY (TenantId, Note(50)),
+    CONSTRAINT UNIQUE INDEX UqReplacedBy (ReplacedBy),
+    KEY IdxPosition (TenantId, Position DESC, Note(20) ASC)
+);
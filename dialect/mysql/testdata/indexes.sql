@@ -0,0 +1,18 @@
+CREATE TABLE Article
+(
+    Id       INT          NOT NULL,
+    Title    VARCHAR(255) NOT NULL,
+    Body     TEXT,
+    Location POINT        NOT NULL,
+    Slug     VARCHAR(64)  NOT NULL,
+    PRIMARY KEY (Id) USING BTREE,
+    UNIQUE KEY UniqueSlug (Slug) USING HASH COMMENT 'lookup',
+    KEY IdxTitle USING BTREE (Title(32)) KEY_BLOCK_SIZE = 8 INVISIBLE,
+    FULLTEXT KEY FtBody (Title, Body) WITH PARSER ngram,
+    SPATIAL INDEX (Location)
+);
+
+CREATE FULLTEXT INDEX FtTitle ON Article (Title) COMMENT 'search';
+CREATE UNIQUE INDEX UniqueTitle ON Article (Title(100)) VISIBLE;
+ALTER TABLE Article ADD INDEX (Slug, Title) USING HASH;
+ALTER TABLE Article ADD SPATIAL KEY SpLocation (Location);
@@ -0,0 +1,45 @@
+CREATE TABLE Event
+(
+    Id      BIGINT   NOT NULL,
+    Created DATETIME NOT NULL
+) ENGINE = InnoDB
+PARTITION BY RANGE (YEAR(Created)) (
+    PARTITION p2020 VALUES LESS THAN (2021) COMMENT = 'archived',
+    PARTITION p2021 VALUES LESS THAN (2022) ENGINE = InnoDB,
+    PARTITION pMax VALUES LESS THAN maxvalue
+);
+
+CREATE TABLE Region
+(
+    Country CHAR(2)     NOT NULL,
+    City    VARCHAR(64) NOT NULL
+)
+PARTITION BY LIST COLUMNS (Country, City) (
+    PARTITION pEU VALUES IN (('DE', 'Berlin'), ('FR', 'Paris')),
+    PARTITION pUS VALUES IN (('US', 'Boston'))
+);
+
+CREATE TABLE Tag
+(
+    Kind INT NOT NULL
+)
+PARTITION BY LIST (Kind) (
+    PARTITION a VALUES IN (1, 2),
+    PARTITION b VALUES IN (3)
+);
+
+CREATE TABLE Session
+(
+    Id INT NOT NULL
+)
+PARTITION BY LINEAR KEY ALGORITHM = 2 (Id) PARTITIONS 4;
+
+CREATE TABLE Log
+(
+    Id INT NOT NULL
+)
+PARTITION BY RANGE COLUMNS (Id)
+SUBPARTITION BY KEY (Id) SUBPARTITIONS 2 (
+    PARTITION p0 VALUES LESS THAN (100),
+    PARTITION p1 VALUES LESS THAN (MAXVALUE)
+);
@@ -0,0 +1,58 @@
+CREATE TABLE Account
+(
+    Id      INT NOT NULL PRIMARY KEY,
+    Balance DECIMAL(10, 2) NOT NULL,
+    Changed DATETIME
+);
+
+CREATE TABLE AccountLog
+(
+    Account INT NOT NULL,
+    Message VARCHAR(255) NOT NULL
+);
+
+DELIMITER $$
+
+CREATE TRIGGER BeforeAccountUpdate BEFORE UPDATE ON Account FOR EACH ROW
+BEGIN
+    SET NEW.Changed = NOW();
+END$$
+
+CREATE DEFINER = 'admin'@'localhost' TRIGGER AfterAccountUpdate AFTER UPDATE ON Account
+    FOR EACH ROW FOLLOWS BeforeAccountUpdate
+    INSERT INTO AccountLog (Account, Message) VALUES (NEW.Id, 'changed; see $$ history')$$
+
+CREATE PROCEDURE Transfer(IN source INT, IN target INT, amount DECIMAL(10, 2), OUT success BOOL)
+    COMMENT 'moves money'
+    MODIFIES SQL DATA
+BEGIN
+    UPDATE Account SET Balance = Balance - amount WHERE Id = source;
+    UPDATE Account SET Balance = Balance + amount WHERE Id = target;
+    SET success = TRUE;
+END $$
+
+CREATE PROCEDURE Archive()
+BEGIN
+    CREATE TEMPORARY TABLE ArchivedLog (Account INT NOT NULL, Message VARCHAR(255) NOT NULL);
+    INSERT INTO ArchivedLog SELECT * FROM AccountLog;
+    ALTER TABLE AccountLog ADD COLUMN Archived DATETIME;
+    CREATE INDEX Archived ON AccountLog (Archived);
+    TRUNCATE TABLE AccountLog;
+    RENAME TABLE ArchivedLog TO OldLog;
+    DROP TABLE OldLog;
+END $$
+
+DELIMITER ;
+
+CREATE FUNCTION Tax(amount DECIMAL(10, 2)) RETURNS DECIMAL(10, 2)
+    DETERMINISTIC NO SQL SQL SECURITY INVOKER
+    RETURN amount * 0.19;
+
+delimiter //
+CREATE FUNCTION Total(account INT) RETURNS DECIMAL(10, 2) READS SQL DATA
+BEGIN
+    DECLARE total DECIMAL(10, 2);
+    SELECT Balance INTO total FROM Account WHERE Id = account;
+    RETURN total;
+END//
+delimiter ;
@@ -0,0 +1,9 @@
+USE shop;
+RENAME TABLE Customer TO Client, crm.Contact TO crm.Person;
+RENAME TABLE crm.Lead TO Prospect;
+ALTER TABLE `Order` RENAME TO Purchase;
+ALTER TABLE crm.Note RENAME AS crm.Remark;
+DROP TABLE IF EXISTS Cart, crm.Basket;
+DROP TABLE Wishlist;
+TRUNCATE TABLE Session;
+TRUNCATE crm.Log;
@@ -0,0 +1,35 @@
+CREATE TABLE Setting
+(
+    Name VARCHAR(64) NOT NULL PRIMARY KEY
+);
+
+CREATE DATABASE IF NOT EXISTS shop CHARACTER SET utf8mb4;
+CREATE SCHEMA crm DEFAULT COLLATE = utf8mb4_bin;
+
+CREATE TABLE crm.Customer
+(
+    Id INT NOT NULL PRIMARY KEY
+);
+
+USE shop;
+
+CREATE TABLE `Order`
+(
+    Id       INT NOT NULL PRIMARY KEY,
+    Customer INT NOT NULL,
+    Product  INT NOT NULL,
+    FOREIGN KEY (Customer) REFERENCES crm.Customer (Id),
+    FOREIGN KEY (Product) REFERENCES Product (Id)
+);
+
+CREATE TABLE `shop`.`Product`
+(
+    Id INT NOT NULL PRIMARY KEY
+);
+
+CREATE VIEW CustomerOrders AS
+SELECT c.Id, o.Id AS OrderId FROM `Order` o JOIN crm.Customer c ON c.Id = o.Customer;
+
+ALTER TABLE crm.Customer ADD COLUMN Email VARCHAR(255);
+CREATE INDEX IdxProduct ON `Order` (Product);
+DROP INDEX IdxProduct ON shop.`Order`;
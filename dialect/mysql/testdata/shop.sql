@@ -0,0 +1,39 @@
+-- A shop schema that uses many MySQL specific features.
+
+CREATE TABLE Product (
+    Id INT NOT NULL,
+    Name VARCHAR(255) NOT NULL,
+    Price INT NOT NULL CHECK (Price > 0) ENFORCED,
+    Discount INT CONSTRAINT DiscountRange CHECK (Discount BETWEEN 0 AND 100),
+    CONSTRAINT DiscountBelowPrice CHECK (Discount < Price) NOT ENFORCED,
+    Total INT AS (Price - Discount),
+    Label VARCHAR(300) GENERATED ALWAYS AS (CONCAT(Name, ' ', Price)) STORED NOT NULL,
+    CHECK (LENGTH(Name) > 2)
+) ENGINE=InnoDB AUTO_INCREMENT=1234 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci
+  ROW_FORMAT=dynamic COMMENT='All products' STATS_PERSISTENT=0;
+
+CREATE TABLE Customer (
+    Id INT NOT NULL AUTO_INCREMENT COMMENT 'The customer''s id',
+    Name VARCHAR(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL,
+    Note TEXT NULL COLLATE utf8mb4_unicode_ci INVISIBLE,
+    Created TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    Updated TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    Touched DATETIME ON UPDATE NOW()
+);
+
+CREATE TABLE Measurement (
+    Amount DECIMAL(10,2) UNSIGNED ZEROFILL,
+    Ratio double precision,
+    Counter BIGINT(20) UNSIGNED NOT NULL,
+    Code CHAR(3) BINARY,
+    Hash BINARY(16),
+    Payload LONG VARBINARY,
+    Title NATIONAL VARCHAR(100),
+    Weight FLOAT(7,4),
+    Exact DECIMAL(8),
+    At DATETIME(6),
+    Mood ENUM('happy','sad') CHARACTER SET latin1,
+    Tags SET('new', 'sale, limited', 'it''s "good"') NOT NULL DEFAULT 'new',
+    Status enum("draft", "published") BINARY,
+    Visible BOOLEAN INVISIBLE
+);
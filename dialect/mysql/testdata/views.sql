@@ -0,0 +1,19 @@
+CREATE TABLE Customer (
+    Id INT PRIMARY KEY,
+    Name VARCHAR(255)
+);
+
+CREATE TABLE Purchase (
+    Id INT PRIMARY KEY,
+    Customer INT,
+    Amount INT
+);
+
+CREATE VIEW CustomerNames AS SELECT Name FROM Customer WHERE Name IS NOT NULL WITH LOCAL CHECK OPTION;
+
+CREATE OR REPLACE ALGORITHM = MERGE DEFINER = CURRENT_USER SQL SECURITY INVOKER
+VIEW `BigSpenders` (Name, Total) AS
+    SELECT c.Name, SUM(p.Amount)
+    FROM Customer c JOIN Purchase p ON p.Customer = c.Id
+    WHERE c.Id IN (SELECT Customer FROM Purchase WHERE Amount > 100)
+    GROUP BY c.Name;
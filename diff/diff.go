@@ -0,0 +1,539 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diff computes the statements, that migrate one set of tables into another.
+package diff
+
+import (
+	"fmt"
+	"github.com/golangee/sql/ddl"
+	"reflect"
+	"strings"
+)
+
+// UnnamedConstraintError signifies that a FOREIGN KEY or CHECK constraint must be dropped,
+// but it cannot be referred to, because it has no name.
+type UnnamedConstraintError struct {
+	Schema string
+	Table  string
+	// Constraint describes the constraint, like FOREIGN KEY (a) REFERENCES b.
+	Constraint string
+}
+
+func (e UnnamedConstraintError) Error() string {
+	table := e.Table
+	if e.Schema != "" {
+		table = e.Schema + "." + e.Table
+	}
+
+	return fmt.Sprintf("%s of table %s has no name and cannot be dropped", e.Constraint, table)
+}
+
+// UnsupportedChangeError signifies that a table differs in a way, that cannot be expressed by
+// ALTER TABLE statements, like a removed table option or a changed partitioning.
+type UnsupportedChangeError struct {
+	Schema string
+	Table  string
+	// Change describes the difference, like removes the option ENGINE.
+	Change string
+}
+
+func (e UnsupportedChangeError) Error() string {
+	table := e.Table
+	if e.Schema != "" {
+		table = e.Schema + "." + e.Table
+	}
+
+	return fmt.Sprintf("table %s %s, which cannot be migrated", table, e.Change)
+}
+
+// Tables returns the statements that migrate the old tables into the new ones, in the order in
+// which they must be applied. Tables are identified by their schema and name, so a renamed
+// table is dropped and created again. See TablesWithRenames to keep renamed tables and columns.
+//
+// Foreign keys are dropped first and added last, so that they never refer to missing
+// tables or indexes. New tables are created without their foreign keys for the same reason.
+func Tables(oldTables, newTables []ddl.Table) ([]ddl.Statement, error) {
+	var dropForeignKeys, dropTables, createTables, alterTables, addForeignKeys []ddl.Statement
+
+	for _, oldTable := range oldTables {
+		if find(newTables, oldTable) == nil {
+			dropTables = append(dropTables, ddl.Statement{Schema: ddl.DropTable{
+				Schema: oldTable.Schema,
+				Table:  oldTable.Name,
+			}})
+		}
+	}
+
+	for _, newTable := range newTables {
+		oldTable := find(oldTables, newTable)
+		if oldTable == nil {
+			created := newTable.Clone()
+			created.IfNotExists = false
+			created.ForeignKeys = nil
+			createTables = append(createTables, ddl.Statement{CreateTable: &created})
+
+			for _, foreignKey := range newTable.ForeignKeys {
+				addForeignKeys = append(addForeignKeys, alter(ddl.AlterAddForeignKey{
+					Schema:     newTable.Schema,
+					Table:      newTable.Name,
+					ForeignKey: foreignKey.Clone(),
+				}))
+			}
+
+			continue
+		}
+
+		drops, err := dropForeignKeyStatements(*oldTable, newTable)
+		if err != nil {
+			return nil, err
+		}
+
+		others, err := tableStatements(*oldTable, newTable)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, statement := range drops {
+			dropForeignKeys = append(dropForeignKeys, alter(statement))
+		}
+
+		for _, statement := range others {
+			alterTables = append(alterTables, alter(statement))
+		}
+
+		for _, statement := range addForeignKeyStatements(*oldTable, newTable) {
+			addForeignKeys = append(addForeignKeys, alter(statement))
+		}
+	}
+
+	var result []ddl.Statement
+	result = append(result, dropForeignKeys...)
+	result = append(result, dropTables...)
+	result = append(result, createTables...)
+	result = append(result, alterTables...)
+	result = append(result, addForeignKeys...)
+
+	return result, nil
+}
+
+// Table returns the ALTER statements that migrate the old definition of a table into the new one.
+func Table(oldTable, newTable ddl.Table) ([]ddl.AlterStatement, error) {
+	drops, err := dropForeignKeyStatements(oldTable, newTable)
+	if err != nil {
+		return nil, err
+	}
+
+	others, err := tableStatements(oldTable, newTable)
+	if err != nil {
+		return nil, err
+	}
+
+	result := append(drops, others...)
+
+	return append(result, addForeignKeyStatements(oldTable, newTable)...), nil
+}
+
+func alter(statement ddl.AlterStatement) ddl.Statement {
+	return ddl.Statement{Alter: statement}
+}
+
+// find returns the table with the same schema and name, or nil if there is none.
+func find(tables []ddl.Table, table ddl.Table) *ddl.Table {
+	for i := range tables {
+		if tables[i].Schema == table.Schema && tables[i].Name == table.Name {
+			return &tables[i]
+		}
+	}
+
+	return nil
+}
+
+// dropForeignKeyStatements drops the foreign keys, that are not part of the new table.
+// A changed foreign key is dropped and added again.
+func dropForeignKeyStatements(oldTable, newTable ddl.Table) ([]ddl.AlterStatement, error) {
+	var result []ddl.AlterStatement
+
+	for _, foreignKey := range oldTable.ForeignKeys {
+		if containsForeignKey(newTable.ForeignKeys, foreignKey) {
+			continue
+		}
+
+		if foreignKey.Name == nil {
+			return nil, UnnamedConstraintError{
+				Schema: oldTable.Schema,
+				Table:  oldTable.Name,
+				Constraint: fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s",
+					strings.Join(foreignKey.Columns, ", "), foreignKey.ReferenceTable),
+			}
+		}
+
+		result = append(result, ddl.AlterDropForeignKey{
+			Schema: oldTable.Schema,
+			Table:  oldTable.Name,
+			Name:   *foreignKey.Name,
+		})
+	}
+
+	return result, nil
+}
+
+// addForeignKeyStatements adds the foreign keys, that are not part of the old table.
+func addForeignKeyStatements(oldTable, newTable ddl.Table) []ddl.AlterStatement {
+	var result []ddl.AlterStatement
+
+	for _, foreignKey := range newTable.ForeignKeys {
+		if !containsForeignKey(oldTable.ForeignKeys, foreignKey) {
+			result = append(result, ddl.AlterAddForeignKey{
+				Schema:     newTable.Schema,
+				Table:      newTable.Name,
+				ForeignKey: foreignKey.Clone(),
+			})
+		}
+	}
+
+	return result
+}
+
+func containsForeignKey(foreignKeys []ddl.ForeignKeyConstraint, foreignKey ddl.ForeignKeyConstraint) bool {
+	for _, other := range foreignKeys {
+		if reflect.DeepEqual(other.Clone(), foreignKey.Clone()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tableStatements returns the statements for everything but foreign keys. Indexes and checks
+// are dropped before the columns, because they may refer to dropped columns, and added after them.
+func tableStatements(oldTable, newTable ddl.Table) ([]ddl.AlterStatement, error) {
+	oldTable, newTable = liftColumnConstraints(oldTable), liftColumnConstraints(newTable)
+
+	var result []ddl.AlterStatement
+
+	dropIndexes, addIndexes := indexStatements(oldTable, newTable)
+	result = append(result, dropIndexes...)
+
+	dropChecks, addChecks, err := checkStatements(oldTable, newTable)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := tableOptionStatements(oldTable, newTable)
+	if err != nil {
+		return nil, err
+	}
+
+	result = append(result, dropChecks...)
+	result = append(result, columnStatements(oldTable, newTable)...)
+	result = append(result, addIndexes...)
+	result = append(result, addChecks...)
+
+	return append(result, options...), nil
+}
+
+// tableOptionStatements returns the statement, that changes the table options. Options, that
+// are missing in the new table, cannot be reset to their defaults, except for the COMMENT.
+func tableOptionStatements(oldTable, newTable ddl.Table) ([]ddl.AlterStatement, error) {
+	unsupported := func(change string) error {
+		return UnsupportedChangeError{Schema: newTable.Schema, Table: newTable.Name, Change: change}
+	}
+
+	if !reflect.DeepEqual(oldTable.Partitioning, newTable.Partitioning) {
+		return nil, unsupported("changes the partitioning")
+	}
+
+	oldOptions, newOptions := oldTable.Options, newTable.Options
+	changed := ddl.TableOptions{}
+
+	options := []struct {
+		name     string
+		old, new *string
+		changed  **string
+	}{
+		{"ENGINE", oldOptions.Engine, newOptions.Engine, &changed.Engine},
+		{"AUTO_INCREMENT", oldOptions.AutoIncrement, newOptions.AutoIncrement, &changed.AutoIncrement},
+		{"CHARACTER SET", oldOptions.CharacterSet, newOptions.CharacterSet, &changed.CharacterSet},
+		{"COLLATE", oldOptions.Collate, newOptions.Collate, &changed.Collate},
+		{"COMMENT", oldOptions.Comment, newOptions.Comment, &changed.Comment},
+		{"ROW_FORMAT", oldOptions.RowFormat, newOptions.RowFormat, &changed.RowFormat},
+	}
+
+	for _, option := range options {
+		switch {
+		case reflect.DeepEqual(option.old, option.new):
+		case option.new != nil:
+			value := *option.new
+			*option.changed = &value
+		case option.name == "COMMENT":
+			// An empty COMMENT removes it.
+			value := ""
+			*option.changed = &value
+		default:
+			return nil, unsupported("removes the option " + option.name)
+		}
+	}
+
+	for _, other := range oldOptions.Others {
+		if !containsTableOption(newOptions.Others, other, false) {
+			return nil, unsupported("removes the option " + ddl.TableOptionName(other))
+		}
+	}
+
+	for _, other := range newOptions.Others {
+		if !containsTableOption(oldOptions.Others, other, true) {
+			changed.Others = append(changed.Others, other)
+		}
+	}
+
+	if reflect.DeepEqual(changed, ddl.TableOptions{}) {
+		return nil, nil
+	}
+
+	return []ddl.AlterStatement{ddl.AlterTableOptions{
+		Schema:  newTable.Schema,
+		Table:   newTable.Name,
+		Options: changed,
+	}}, nil
+}
+
+// containsTableOption returns true, if an option of Others has the same name. If exact is
+// set, it must have the same value, too.
+func containsTableOption(options []string, option string, exact bool) bool {
+	for _, other := range options {
+		if exact && other == option || !exact && strings.EqualFold(ddl.TableOptionName(other), ddl.TableOptionName(option)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// liftColumnConstraints moves column level PRIMARY KEY and UNIQUE constraints to the table,
+// so that they are compared like table level constraints.
+func liftColumnConstraints(table ddl.Table) ddl.Table {
+	table = table.Clone()
+
+	for i, column := range table.Columns {
+		if column.PrimaryKey {
+			table.PrimaryKey = &ddl.PrimaryKeyConstraint{Parts: []ddl.IndexPart{{Column: column.Name}}}
+			table.Columns[i].PrimaryKey = false
+		}
+
+		if column.Unique {
+			name := column.Name
+			table.UniqueConstraints = append(table.UniqueConstraints, ddl.UniqueConstraint{
+				Name:  &name,
+				Parts: []ddl.IndexPart{{Column: column.Name}},
+			})
+			table.Columns[i].Unique = false
+		}
+	}
+
+	return table
+}
+
+// indexStatements returns the statements to drop the indexes, that are missing or changed
+// in the new table, and the statements to add them.
+func indexStatements(oldTable, newTable ddl.Table) ([]ddl.AlterStatement, []ddl.AlterStatement) {
+	var drops, adds []ddl.AlterStatement
+
+	for _, index := range oldTable.Indexes() {
+		if newIndex, ok := newTable.Index(index.Name); ok && equalIndexes(index, newIndex) {
+			continue
+		}
+
+		if index.Primary {
+			drops = append(drops, ddl.AlterDropPrimaryKey{Schema: oldTable.Schema, Table: oldTable.Name})
+		} else {
+			drops = append(drops, ddl.AlterDropIndex{Schema: oldTable.Schema, Table: oldTable.Name, Index: index.Name})
+		}
+	}
+
+	for _, index := range newTable.Indexes() {
+		if oldIndex, ok := oldTable.Index(index.Name); ok && equalIndexes(oldIndex, index) {
+			continue
+		}
+
+		if index.Primary {
+			adds = append(adds, ddl.AlterAddPrimaryKey{
+				Schema:     newTable.Schema,
+				Table:      newTable.Name,
+				PrimaryKey: newTable.PrimaryKey.Clone(),
+			})
+
+			continue
+		}
+
+		adds = append(adds, ddl.AlterAddIndex{
+			Schema:  newTable.Schema,
+			Table:   newTable.Name,
+			Name:    index.Name,
+			Parts:   index.Parts,
+			Unique:  index.Unique,
+			Kind:    index.Kind,
+			Options: index.Options,
+		})
+	}
+
+	return drops, adds
+}
+
+// equalIndexes compares two indexes with the same name.
+func equalIndexes(a, b ddl.Index) bool {
+	return a.Primary == b.Primary && a.Unique == b.Unique && a.Kind == b.Kind &&
+		equalParts(a.Parts, b.Parts) && reflect.DeepEqual(a.Options, b.Options)
+}
+
+// equalParts compares the parts of two indexes, ignoring the case of the column names.
+func equalParts(a, b []ddl.IndexPart) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !strings.EqualFold(a[i].Column, b[i].Column) || a[i].Length != b[i].Length || a[i].Descending != b[i].Descending {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkStatements returns the statements to drop the table level checks, that are missing or
+// changed in the new table, and the statements to add them.
+func checkStatements(oldTable, newTable ddl.Table) ([]ddl.AlterStatement, []ddl.AlterStatement, error) {
+	var drops, adds []ddl.AlterStatement
+
+	for _, check := range oldTable.Checks {
+		if containsCheck(newTable.Checks, check) {
+			continue
+		}
+
+		if check.Name == nil {
+			return nil, nil, UnnamedConstraintError{
+				Schema:     oldTable.Schema,
+				Table:      oldTable.Name,
+				Constraint: fmt.Sprintf("CHECK (%s)", check.Expression),
+			}
+		}
+
+		drops = append(drops, ddl.AlterDropConstraint{
+			Schema: oldTable.Schema,
+			Table:  oldTable.Name,
+			Name:   *check.Name,
+			Check:  true,
+		})
+	}
+
+	for _, check := range newTable.Checks {
+		if !containsCheck(oldTable.Checks, check) {
+			adds = append(adds, ddl.AlterAddCheck{Schema: newTable.Schema, Table: newTable.Name, Check: check})
+		}
+	}
+
+	return drops, adds, nil
+}
+
+func containsCheck(checks []ddl.CheckConstraint, check ddl.CheckConstraint) bool {
+	for _, other := range checks {
+		if reflect.DeepEqual(other, check) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// columnStatements drops the columns, that are missing in the new table, and then adds,
+// modifies and moves the others from the first to the last, until they match the new table.
+func columnStatements(oldTable, newTable ddl.Table) []ddl.AlterStatement {
+	var result []ddl.AlterStatement
+
+	// The names of the columns after the statements so far.
+	var current []string
+
+	for _, column := range oldTable.Columns {
+		if newTable.Column(column.Name) == nil {
+			result = append(result, ddl.AlterDropColumn{
+				Schema: oldTable.Schema,
+				Table:  oldTable.Name,
+				Column: column.Name,
+			})
+
+			continue
+		}
+
+		current = append(current, column.Name)
+	}
+
+	for i, column := range newTable.Columns {
+		first := i == 0
+
+		var after *string
+		if i > 0 {
+			after = &newTable.Columns[i-1].Name
+		}
+
+		oldColumn := oldTable.Column(column.Name)
+		if oldColumn == nil {
+			result = append(result, ddl.AlterAddColumn{
+				Schema: newTable.Schema,
+				Table:  newTable.Name,
+				Column: column.Clone(),
+				First:  first,
+				After:  after,
+			})
+			current = insert(current, i, column.Name)
+
+			continue
+		}
+
+		moved := !strings.EqualFold(current[i], column.Name)
+		if !moved && oldColumn.Equal(column) {
+			continue
+		}
+
+		modify := ddl.AlterModifyColumn{
+			Schema: newTable.Schema,
+			Table:  newTable.Name,
+			Column: column.Clone(),
+		}
+
+		if moved {
+			modify.First = first
+			modify.After = after
+			current = insert(remove(current, column.Name), i, column.Name)
+		}
+
+		result = append(result, modify)
+	}
+
+	return result
+}
+
+func insert(names []string, index int, name string) []string {
+	return append(names[:index], append([]string{name}, names[index:]...)...)
+}
+
+func remove(names []string, name string) []string {
+	for i := range names {
+		if strings.EqualFold(names[i], name) {
+			return append(names[:i], names[i+1:]...)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,279 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff_test
+
+import (
+	"errors"
+	"github.com/golangee/sql/ddl"
+	"github.com/golangee/sql/dialect/mysql"
+	"github.com/golangee/sql/diff"
+	"github.com/golangee/sql/normalize"
+	"io/ioutil"
+	"testing"
+)
+
+func parseTables(t *testing.T, fname string) []ddl.Table {
+	t.Helper()
+
+	sql, err := ioutil.ReadFile("testdata/" + fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := mysql.Parse(string(sql))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result.Tables
+}
+
+// testDiff diffs the tables and checks the resulting statements.
+func testDiff(t *testing.T, oldTables, newTables []ddl.Table) []ddl.Statement {
+	t.Helper()
+
+	statements, err := diff.Tables(oldTables, newTables)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testApply(t, oldTables, newTables, statements)
+
+	return statements
+}
+
+// testApply checks, that applying the statements to the old tables yields the new ones.
+func testApply(t *testing.T, oldTables, newTables []ddl.Table, statements []ddl.Statement) {
+	t.Helper()
+
+	schema := ddl.Schema{Tables: oldTables}.Clone()
+	if err := schema.Apply("", &ddl.ParseResult{Statements: statements}); err != nil {
+		t.Fatalf("%v in %s", err, normalize.Statements(statements))
+	}
+
+	if len(schema.Tables) != len(newTables) {
+		t.Fatalf("Expected %d tables, but got %d", len(newTables), len(schema.Tables))
+	}
+
+	for _, newTable := range newTables {
+		table := schema.Table(newTable.Schema + "." + newTable.Name)
+		if table == nil {
+			t.Fatalf("Expected table %s to exist", newTable.Name)
+		}
+
+		if !table.Equal(newTable) {
+			t.Errorf("Table %s differs from the target:\n%s\n%s", newTable.Name,
+				normalize.Table(*table), normalize.Table(newTable))
+		}
+	}
+}
+
+func TestTables(t *testing.T) {
+	oldTables := parseTables(t, "shop-old.sql")
+	newTables := parseTables(t, "shop-new.sql")
+
+	statements := testDiff(t, oldTables, newTables)
+
+	expected := "ALTER TABLE `Order` DROP FOREIGN KEY `OrderCart`;" +
+		"DROP TABLE `Cart`;" +
+		"CREATE TABLE `Invoice` (`Id` INT NOT NULL,`OrderId` INT NOT NULL,PRIMARY KEY (`Id`));" +
+		"ALTER TABLE `Customer` DROP INDEX `Name`;" +
+		"ALTER TABLE `Customer` ADD COLUMN `Email` VARCHAR(255) NOT NULL AFTER `Id`;" +
+		"ALTER TABLE `Customer` MODIFY COLUMN `Name` VARCHAR(200) NOT NULL;" +
+		"CREATE UNIQUE INDEX `Email` ON `Customer`(`Email`);" +
+		"CREATE INDEX `Name` ON `Customer`(`Name`(20));" +
+		"ALTER TABLE `Order` DROP COLUMN `CartId`;" +
+		"ALTER TABLE `Order` MODIFY COLUMN `Total` DECIMAL(10,2) NOT NULL FIRST;" +
+		"ALTER TABLE `Order` ADD CONSTRAINT `PositiveTotal` CHECK (Total >= 0);" +
+		"ALTER TABLE `Invoice` ADD CONSTRAINT `InvoiceOrder` FOREIGN KEY (`OrderId`) REFERENCES `Order`(`Id`);"
+
+	if actual := normalize.Statements(statements); actual != expected {
+		t.Fatalf("Unexpected statements:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestTables_Unchanged(t *testing.T) {
+	tables := parseTables(t, "shop-old.sql")
+
+	if statements := testDiff(t, tables, tables); len(statements) != 0 {
+		t.Fatalf("Expected no statements, but got %s", normalize.Statements(statements))
+	}
+}
+
+func TestTables_Equivalent(t *testing.T) {
+	oldTables := parseSQL(t, "CREATE TABLE T (Id INT NOT NULL, a INT NULL, b INT, CONSTRAINT pk PRIMARY KEY (Id), KEY ab (a, b));")
+	newTables := parseSQL(t, "CREATE TABLE T (ID INT NOT NULL, A INT, b INT, PRIMARY KEY (id), KEY AB (A, B));")
+
+	if statements := testDiff(t, oldTables, newTables); len(statements) != 0 {
+		t.Fatalf("Expected no statements, but got %s", normalize.Statements(statements))
+	}
+}
+
+func TestTables_UnnamedIndexes(t *testing.T) {
+	oldTables := parseSQL(t, "CREATE TABLE T (a INT, b INT, c INT, KEY (a), UNIQUE KEY (b, c));")
+	newTables := parseSQL(t, "CREATE TABLE T (a INT, b INT, c INT, KEY (c), UNIQUE KEY (b, a));")
+
+	statements := testDiff(t, oldTables, newTables)
+
+	expected := "ALTER TABLE `T` DROP INDEX `b`;" +
+		"ALTER TABLE `T` DROP INDEX `a`;" +
+		"CREATE UNIQUE INDEX `b` ON `T`(`b`,`a`);" +
+		"CREATE INDEX `c` ON `T`(`c`);"
+
+	if actual := normalize.Statements(statements); actual != expected {
+		t.Fatalf("Unexpected statements:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestTables_UnnamedIndexesOnSameColumn(t *testing.T) {
+	oldTables := parseSQL(t, "CREATE TABLE T (a INT, b INT, KEY (a), KEY (a, b));")
+	newTables := parseSQL(t, "CREATE TABLE T (a INT, b INT, KEY (a, b));")
+
+	statements := testDiff(t, oldTables, newTables)
+
+	// MySQL names the second index a_2.
+	expected := "ALTER TABLE `T` DROP INDEX `a`;" +
+		"ALTER TABLE `T` DROP INDEX `a_2`;" +
+		"CREATE INDEX `a` ON `T`(`a`,`b`);"
+
+	if actual := normalize.Statements(statements); actual != expected {
+		t.Fatalf("Unexpected statements:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestTables_Options(t *testing.T) {
+	oldTables := parseSQL(t, "CREATE TABLE T (a INT) ENGINE=InnoDB COMMENT='old' STATS_PERSISTENT=0 STATS_AUTO_RECALC=1;")
+	newTables := parseSQL(t, "CREATE TABLE T (a INT) ENGINE=MyISAM STATS_AUTO_RECALC=1 STATS_PERSISTENT=1;")
+
+	statements := testDiff(t, oldTables, newTables)
+
+	expected := "ALTER TABLE `T` COMMENT='' ENGINE=MyISAM STATS_PERSISTENT=1;"
+	if actual := normalize.Statements(statements); actual != expected {
+		t.Fatalf("Unexpected statements:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestTables_UnsupportedChange(t *testing.T) {
+	oldTables := parseSQL(t, "CREATE TABLE T (a INT) ENGINE=InnoDB;")
+	newTables := parseSQL(t, "CREATE TABLE T (a INT) PARTITION BY HASH(a) PARTITIONS 2;")
+
+	var unsupported diff.UnsupportedChangeError
+	if _, err := diff.Tables(oldTables, newTables); !errors.As(err, &unsupported) {
+		t.Fatalf("Expected an UnsupportedChangeError for the partitioning, but got %v", err)
+	}
+
+	newTables = parseSQL(t, "CREATE TABLE T (a INT);")
+	if _, err := diff.Tables(oldTables, newTables); !errors.As(err, &unsupported) {
+		t.Fatalf("Expected an UnsupportedChangeError for the removed ENGINE, but got %v", err)
+	}
+}
+
+func parseSQL(t *testing.T, sql string) []ddl.Table {
+	t.Helper()
+
+	result, err := mysql.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result.Tables
+}
+
+func TestTables_Reverse(t *testing.T) {
+	testDiff(t, parseTables(t, "shop-new.sql"), parseTables(t, "shop-old.sql"))
+}
+
+func TestTable_UnnamedConstraint(t *testing.T) {
+	oldTable := ddl.Table{
+		Name:        "Order",
+		Columns:     []ddl.Column{{Name: "CustomerId"}},
+		ForeignKeys: []ddl.ForeignKeyConstraint{{Columns: []string{"CustomerId"}, ReferenceTable: "Customer"}},
+	}
+
+	newTable := oldTable.Clone()
+	newTable.ForeignKeys = nil
+
+	var unnamed diff.UnnamedConstraintError
+	if _, err := diff.Table(oldTable, newTable); !errors.As(err, &unnamed) {
+		t.Fatalf("Expected an UnnamedConstraintError, but got %v", err)
+	}
+
+	// Adding it is fine.
+	statements, err := diff.Table(newTable, oldTable)
+	if err != nil || len(statements) != 1 {
+		t.Fatalf("Expected a single statement, got %v", err)
+	}
+}
+
+func TestRenames(t *testing.T) {
+	oldTables := parseTables(t, "rename-old.sql")
+	newTables := parseTables(t, "rename-new.sql")
+
+	renames := diff.Renames(oldTables, newTables)
+
+	expected := []diff.Rename{
+		{Kind: diff.RenameColumn, Table: "Customer", OldName: "Mail", NewName: "Email"},
+		{Kind: diff.RenameTable, OldName: "Item", NewName: "Items"},
+		{Kind: diff.RenameColumn, Table: "Order", OldName: "Note", NewName: "Remark"},
+	}
+
+	if len(renames) != len(expected) {
+		t.Fatalf("Expected %d renames, but got %v", len(expected), renames)
+	}
+
+	for i, rename := range renames {
+		rename.Confidence = 0
+		if rename != expected[i] {
+			t.Errorf("Expected rename %v, but got %v", expected[i], rename)
+		}
+	}
+
+	if renames[0].Confidence < 0.9 || renames[2].Confidence > 0.7 {
+		t.Errorf("Unexpected confidence of the renamed columns: %v", renames)
+	}
+
+	accepted := diff.Accepted(renames, 0.8)
+	if len(accepted) != 2 {
+		t.Fatalf("Expected the first two renames to be accepted, but got %v", accepted)
+	}
+
+	statements, err := diff.TablesWithRenames(oldTables, newTables, accepted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testApply(t, oldTables, newTables, statements)
+
+	expectedStatements := "RENAME TABLE `Item` TO `Items`;" +
+		"ALTER TABLE `Customer` RENAME COLUMN `Mail` TO `Email`;" +
+		"ALTER TABLE `Order` DROP COLUMN `Note`;" +
+		"ALTER TABLE `Order` ADD COLUMN `Remark` TEXT AFTER `ItemId`;"
+
+	if actual := normalize.Statements(statements); actual != expectedStatements {
+		t.Fatalf("Unexpected statements:\n%s\nexpected:\n%s", actual, expectedStatements)
+	}
+}
+
+func TestRenames_Unrelated(t *testing.T) {
+	oldTables := []ddl.Table{{Name: "Cart", Columns: []ddl.Column{{Name: "Id", Type: ddl.DataType{Name: "INT"}}}}}
+	newTables := []ddl.Table{{Name: "Invoice", Columns: []ddl.Column{
+		{Name: "Id", Type: ddl.DataType{Name: "BIGINT"}},
+		{Name: "Total", Type: ddl.DataType{Name: "INT"}},
+	}}}
+
+	if renames := diff.Renames(oldTables, newTables); len(renames) != 0 {
+		t.Fatalf("Expected no renames, but got %v", renames)
+	}
+}
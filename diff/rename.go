@@ -0,0 +1,289 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"github.com/golangee/sql/ddl"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// RenameKind distinguishes renamed tables from renamed columns.
+type RenameKind string
+
+const (
+	RenameTable  RenameKind = "TABLE"
+	RenameColumn RenameKind = "COLUMN"
+)
+
+// Rename is a table or column that might have been renamed instead of being dropped and added.
+// A naive diff loses the data of a renamed column, so candidates should be confirmed.
+type Rename struct {
+	Kind RenameKind
+	// Schema is the database of the table.
+	Schema string
+	// Table is the table that contains a renamed column. Empty for a renamed table.
+	Table string
+	// OldName is the name of the table or column in the old tables.
+	OldName string
+	// NewName is the name of the table or column in the new tables.
+	NewName string
+	// Confidence is between 0 and 1. It is higher for similar names, equal definitions and,
+	// for columns, an unchanged position in the table.
+	Confidence float64
+}
+
+func (r Rename) statement() ddl.Statement {
+	if r.Kind == RenameTable {
+		return ddl.Statement{Schema: ddl.RenameTable{
+			Schema:    r.Schema,
+			Table:     r.OldName,
+			NewSchema: r.Schema,
+			NewTable:  r.NewName,
+		}}
+	}
+
+	return ddl.Statement{Alter: ddl.AlterRenameColumn{
+		Schema:  r.Schema,
+		Table:   r.Table,
+		OldName: r.OldName,
+		NewName: r.NewName,
+	}}
+}
+
+// Renames returns the candidates for renamed tables and columns, with the most confident first.
+// Each dropped table or column is the candidate of at most one added one. Only tables of the
+// same database with a column in common, and only columns of the same type are candidates.
+// Renamed columns are only detected in tables that have the same name in both sets.
+func Renames(oldTables, newTables []ddl.Table) []Rename {
+	var candidates []Rename
+
+	for _, oldTable := range oldTables {
+		if find(newTables, oldTable) != nil {
+			continue
+		}
+
+		for _, newTable := range newTables {
+			if newTable.Schema != oldTable.Schema || find(oldTables, newTable) != nil {
+				continue
+			}
+
+			if common := commonColumns(oldTable, newTable); common > 0 {
+				candidates = append(candidates, Rename{
+					Kind:       RenameTable,
+					Schema:     oldTable.Schema,
+					OldName:    oldTable.Name,
+					NewName:    newTable.Name,
+					Confidence: 0.4*nameSimilarity(oldTable.Name, newTable.Name) + 0.6*common,
+				})
+			}
+		}
+	}
+
+	for _, newTable := range newTables {
+		if oldTable := find(oldTables, newTable); oldTable != nil {
+			candidates = append(candidates, columnRenames(*oldTable, newTable)...)
+		}
+	}
+
+	return assign(candidates)
+}
+
+// commonColumns returns the share of columns, that are equal in both tables.
+func commonColumns(oldTable, newTable ddl.Table) float64 {
+	oldTable, newTable = liftColumnConstraints(oldTable), liftColumnConstraints(newTable)
+	common := 0
+
+	for _, column := range oldTable.Columns {
+		if newColumn := newTable.Column(column.Name); newColumn != nil && newColumn.Equal(column) {
+			common++
+		}
+	}
+
+	total := len(oldTable.Columns)
+	if len(newTable.Columns) > total {
+		total = len(newTable.Columns)
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(common) / float64(total)
+}
+
+// columnRenames returns the candidates for the dropped columns of a table.
+func columnRenames(oldTable, newTable ddl.Table) []Rename {
+	var candidates []Rename
+
+	for i, oldColumn := range oldTable.Columns {
+		if newTable.Column(oldColumn.Name) != nil {
+			continue
+		}
+
+		for j, newColumn := range newTable.Columns {
+			if oldTable.Column(newColumn.Name) != nil || !reflect.DeepEqual(newColumn.Type.Clone(), oldColumn.Type.Clone()) {
+				continue
+			}
+
+			confidence := 0.4 * nameSimilarity(oldColumn.Name, newColumn.Name)
+
+			renamed := oldColumn.Clone()
+			renamed.Name = newColumn.Name
+
+			if renamed.Equal(newColumn) {
+				confidence += 0.4
+			}
+
+			if i == j {
+				confidence += 0.2
+			}
+
+			candidates = append(candidates, Rename{
+				Kind:       RenameColumn,
+				Schema:     newTable.Schema,
+				Table:      newTable.Name,
+				OldName:    oldColumn.Name,
+				NewName:    newColumn.Name,
+				Confidence: confidence,
+			})
+		}
+	}
+
+	return candidates
+}
+
+// assign picks the most confident candidates, so that each old and each new name is used once.
+func assign(candidates []Rename) []Rename {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+
+	used := make(map[string]bool)
+
+	var result []Rename
+
+	for _, candidate := range candidates {
+		oldKey := strings.Join([]string{string(candidate.Kind), candidate.Schema, candidate.Table, "old", candidate.OldName}, ".")
+		newKey := strings.Join([]string{string(candidate.Kind), candidate.Schema, candidate.Table, "new", candidate.NewName}, ".")
+
+		if used[oldKey] || used[newKey] {
+			continue
+		}
+
+		used[oldKey], used[newKey] = true, true
+		result = append(result, candidate)
+	}
+
+	return result
+}
+
+// Accepted returns the renames with a confidence of at least the threshold.
+func Accepted(renames []Rename, threshold float64) []Rename {
+	var result []Rename
+
+	for _, rename := range renames {
+		if rename.Confidence >= threshold {
+			result = append(result, rename)
+		}
+	}
+
+	return result
+}
+
+// TablesWithRenames is like Tables, but renames the given tables and columns, instead of dropping
+// and adding them. Table renames come first, so that Rename.Table of a column refers to the new name.
+// The renames are usually the confirmed or Accepted candidates of Renames.
+func TablesWithRenames(oldTables, newTables []ddl.Table, renames []Rename) ([]ddl.Statement, error) {
+	var statements []ddl.Statement
+
+	for _, kind := range []RenameKind{RenameTable, RenameColumn} {
+		for _, rename := range renames {
+			if rename.Kind == kind {
+				statements = append(statements, rename.statement())
+			}
+		}
+	}
+
+	// Diff the renamed tables, which may still differ in other ways.
+	schema := ddl.Schema{Tables: oldTables}.Clone()
+	if err := schema.Apply("", &ddl.ParseResult{Statements: statements}); err != nil {
+		return nil, err
+	}
+
+	others, err := Tables(schema.Tables, newTables)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(statements, others...), nil
+}
+
+// nameSimilarity returns 1 for names, that only differ in their case, down to 0 for entirely different names.
+// It is based on the edit distance of the names.
+func nameSimilarity(a, b string) float64 {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(editDistance(a, b))/float64(longest)
+}
+
+// editDistance returns the Levenshtein distance of two strings.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func min(values ...int) int {
+	result := values[0]
+
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+
+	return result
+}
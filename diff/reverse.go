@@ -0,0 +1,120 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"github.com/golangee/sql/analyze"
+	"github.com/golangee/sql/ddl"
+)
+
+// Down is the reverse of a migration, which restores the tables before it.
+type Down struct {
+	// Statements undo the migration, in the order in which they must be applied.
+	Statements []ddl.Statement
+	// Irreversible are the statements of the migration, that Statements cannot undo completely.
+	Irreversible []Irreversible
+}
+
+// Irreversible is a statement, whose effect cannot be undone completely, like a dropped column,
+// which is added again but without its values.
+type Irreversible struct {
+	Statement ddl.Statement
+	// Reason explains what cannot be restored.
+	Reason string
+}
+
+// Reverse returns the statements that undo the given statements, which are applied in order to the
+// given tables. Each statement is undone against the tables right before it, so that e.g. a dropped
+// column is added again with its former definition and position. The tables are not changed.
+// Returns a ddl.ApplyError, if a statement cannot be applied.
+func Reverse(tables []ddl.Table, statements []ddl.Statement) (Down, error) {
+	var down Down
+
+	schema := ddl.Schema{Tables: tables}.Clone()
+
+	for _, statement := range statements {
+		// Applying replaces the altered table in the slice, so keep a copy.
+		before := ddl.Schema{Tables: schema.Tables}.Clone().Tables
+
+		findings, err := analyze.Statements(before, []ddl.Statement{statement})
+		if err != nil {
+			return Down{}, err
+		}
+
+		if err := schema.Apply("", &ddl.ParseResult{Statements: []ddl.Statement{statement}}); err != nil {
+			return Down{}, err
+		}
+
+		// Adding a column loses no data of the tables before.
+		if _, added := statement.Alter.(ddl.AlterAddColumn); !added {
+			for _, reason := range findings[0].Reasons {
+				if reason.Severity == analyze.DataLoss {
+					down.Irreversible = append(down.Irreversible, Irreversible{Statement: statement, Reason: reason.Message})
+				}
+			}
+		}
+
+		inverse, err := reverseStatement(schema.Tables, before, statement)
+		if err != nil {
+			down.Irreversible = append(down.Irreversible, Irreversible{Statement: statement, Reason: err.Error()})
+
+			continue
+		}
+
+		down.Statements = append(inverse, down.Statements...)
+	}
+
+	return down, nil
+}
+
+// ReverseTable returns the statements that undo the given ALTER statements of a table, like Reverse.
+func ReverseTable(table ddl.Table, statements []ddl.AlterStatement) (Down, error) {
+	wrapped := make([]ddl.Statement, 0, len(statements))
+	for _, statement := range statements {
+		wrapped = append(wrapped, alter(statement))
+	}
+
+	return Reverse([]ddl.Table{table}, wrapped)
+}
+
+// reverseStatement returns the statements that migrate the tables after a statement into those before it.
+func reverseStatement(after, before []ddl.Table, statement ddl.Statement) ([]ddl.Statement, error) {
+	var renames []Rename
+
+	switch stmt := statement.Alter.(type) {
+	case ddl.AlterRenameColumn:
+		renames = append(renames, columnRename(stmt.Schema, stmt.Table, stmt.NewName, stmt.OldName))
+	case ddl.AlterChangeColumn:
+		if stmt.Column.Name != stmt.OldName {
+			renames = append(renames, columnRename(stmt.Schema, stmt.Table, stmt.Column.Name, stmt.OldName))
+		}
+	}
+
+	// A table may be moved to another database, which a Rename cannot express.
+	if rename, ok := statement.Schema.(ddl.RenameTable); ok {
+		return []ddl.Statement{{Schema: ddl.RenameTable{
+			Schema:    rename.NewSchema,
+			Table:     rename.NewTable,
+			NewSchema: rename.Schema,
+			NewTable:  rename.Table,
+		}}}, nil
+	}
+
+	return TablesWithRenames(after, before, renames)
+}
+
+func columnRename(schema, table, oldName, newName string) Rename {
+	return Rename{Kind: RenameColumn, Schema: schema, Table: table, OldName: oldName, NewName: newName, Confidence: 1}
+}
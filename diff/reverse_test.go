@@ -0,0 +1,104 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff_test
+
+import (
+	"errors"
+	"github.com/golangee/sql/ddl"
+	"github.com/golangee/sql/dialect/mysql"
+	"github.com/golangee/sql/diff"
+	"github.com/golangee/sql/normalize"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestReverse(t *testing.T) {
+	oldTables := parseTables(t, "shop-old.sql")
+
+	sql, err := ioutil.ReadFile("testdata/shop-migration.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migration, err := mysql.Parse(string(sql))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	down, err := diff.Reverse(oldTables, migration.Statements)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrated := ddl.Schema{Tables: oldTables}.Clone()
+	if err := migrated.Apply("", migration); err != nil {
+		t.Fatal(err)
+	}
+
+	testApply(t, migrated.Tables, oldTables, down.Statements)
+
+	expected := "RENAME TABLE `Client` TO `Customer`;" +
+		"CREATE TABLE `Cart` (`Id` INT NOT NULL,PRIMARY KEY (`Id`));" +
+		"ALTER TABLE `Order` MODIFY COLUMN `Total` DECIMAL(10,2) NOT NULL;" +
+		"ALTER TABLE `Order` ADD COLUMN `CartId` INT AFTER `CustomerId`;" +
+		"ALTER TABLE `Order` ADD CONSTRAINT `OrderCart` FOREIGN KEY (`CartId`) REFERENCES `Cart`(`Id`);" +
+		"ALTER TABLE `Customer` DROP INDEX `Email`;" +
+		"ALTER TABLE `Customer` RENAME COLUMN `FullName` TO `Name`;" +
+		"ALTER TABLE `Customer` DROP COLUMN `Email`;"
+
+	if actual := normalize.Statements(down.Statements); actual != expected {
+		t.Fatalf("Unexpected statements:\n%s\nexpected:\n%s", actual, expected)
+	}
+
+	var irreversible []string
+	for _, step := range down.Irreversible {
+		irreversible = append(irreversible, step.Statement.String()+": "+step.Reason)
+	}
+
+	expectedIrreversible := []string{
+		"ALTER TABLE Order: drops column CartId and its values",
+		"ALTER TABLE Order: narrows column Total from DECIMAL(10,2) to DECIMAL(8,2), which may truncate its values",
+		"DROP TABLE Cart: drops table Cart and all of its rows",
+		"TRUNCATE TABLE Order: deletes all rows of table Order",
+	}
+
+	if strings.Join(irreversible, "\n") != strings.Join(expectedIrreversible, "\n") {
+		t.Fatalf("Unexpected irreversible statements:\n%s\nexpected:\n%s",
+			strings.Join(irreversible, "\n"), strings.Join(expectedIrreversible, "\n"))
+	}
+}
+
+func TestReverseTable_UnnamedConstraint(t *testing.T) {
+	table := ddl.Table{Name: "Order", Columns: []ddl.Column{{Name: "CustomerId"}}}
+
+	down, err := diff.ReverseTable(table, []ddl.AlterStatement{ddl.AlterAddForeignKey{
+		Table:      "Order",
+		ForeignKey: ddl.ForeignKeyConstraint{Columns: []string{"CustomerId"}, ReferenceTable: "Customer"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(down.Statements) != 0 || len(down.Irreversible) != 1 {
+		t.Fatalf("Expected a single irreversible statement, but got %v", down)
+	}
+
+	// Statements that cannot be applied cannot be reversed.
+	var applyErr ddl.ApplyError
+	if _, err := diff.ReverseTable(table, []ddl.AlterStatement{ddl.AlterDropColumn{Table: "Order", Column: "Total"}}); !errors.As(err, &applyErr) {
+		t.Fatalf("Expected an ApplyError, but got %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+CREATE TABLE Customer (
+  Id INT NOT NULL PRIMARY KEY,
+  Email VARCHAR(255) NOT NULL,
+  Name VARCHAR(100) NOT NULL
+);
+
+CREATE TABLE Items (
+  Id INT NOT NULL PRIMARY KEY,
+  Title VARCHAR(100) NOT NULL
+);
+
+CREATE TABLE `Order` (
+  Id INT NOT NULL PRIMARY KEY,
+  ItemId INT NOT NULL,
+  Remark TEXT,
+  CONSTRAINT OrderItem FOREIGN KEY (ItemId) REFERENCES Items (Id)
+);
@@ -0,0 +1,17 @@
+CREATE TABLE Customer (
+  Id INT NOT NULL PRIMARY KEY,
+  Mail VARCHAR(255) NOT NULL,
+  Name VARCHAR(100) NOT NULL
+);
+
+CREATE TABLE Item (
+  Id INT NOT NULL PRIMARY KEY,
+  Title VARCHAR(100) NOT NULL
+);
+
+CREATE TABLE `Order` (
+  Id INT NOT NULL PRIMARY KEY,
+  ItemId INT NOT NULL,
+  Note TEXT,
+  CONSTRAINT OrderItem FOREIGN KEY (ItemId) REFERENCES Item (Id)
+);
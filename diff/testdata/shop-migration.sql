@@ -0,0 +1,9 @@
+ALTER TABLE Customer ADD COLUMN Email VARCHAR(255) NOT NULL DEFAULT '' AFTER Id;
+ALTER TABLE Customer RENAME COLUMN Name TO FullName;
+CREATE INDEX Email ON Customer (Email);
+ALTER TABLE `Order` DROP FOREIGN KEY OrderCart;
+ALTER TABLE `Order` DROP COLUMN CartId;
+ALTER TABLE `Order` MODIFY Total DECIMAL(8,2) NOT NULL;
+DROP TABLE Cart;
+RENAME TABLE Customer TO Client;
+TRUNCATE TABLE `Order`;
@@ -0,0 +1,21 @@
+CREATE TABLE Customer (
+  Id INT NOT NULL,
+  Email VARCHAR(255) NOT NULL UNIQUE,
+  Name VARCHAR(200) NOT NULL,
+  PRIMARY KEY (Id),
+  KEY Name (Name(20))
+);
+
+CREATE TABLE `Order` (
+  Total DECIMAL(10,2) NOT NULL,
+  Id INT NOT NULL PRIMARY KEY,
+  CustomerId INT NOT NULL,
+  CONSTRAINT OrderCustomer FOREIGN KEY (CustomerId) REFERENCES Customer (Id),
+  CONSTRAINT PositiveTotal CHECK (Total >= 0)
+);
+
+CREATE TABLE Invoice (
+  Id INT NOT NULL PRIMARY KEY,
+  OrderId INT NOT NULL,
+  CONSTRAINT InvoiceOrder FOREIGN KEY (OrderId) REFERENCES `Order` (Id)
+);
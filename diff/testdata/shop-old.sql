@@ -0,0 +1,19 @@
+CREATE TABLE Customer (
+  Id INT NOT NULL PRIMARY KEY,
+  Name VARCHAR(100) NOT NULL,
+  UNIQUE KEY Name (Name)
+);
+
+CREATE TABLE Cart (
+  Id INT NOT NULL PRIMARY KEY
+);
+
+CREATE TABLE `Order` (
+  Id INT NOT NULL,
+  CustomerId INT NOT NULL,
+  CartId INT,
+  Total DECIMAL(10,2) NOT NULL,
+  PRIMARY KEY (Id),
+  CONSTRAINT OrderCustomer FOREIGN KEY (CustomerId) REFERENCES Customer (Id),
+  CONSTRAINT OrderCart FOREIGN KEY (CartId) REFERENCES Cart (Id)
+);
@@ -0,0 +1,211 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package migrate loads a directory of versioned migration files, like 0001_init.sql and
+// 0002_add_users.sql, and replays them into a ddl.Schema.
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/golangee/sql/ddl"
+	"github.com/golangee/sql/dialect/mysql"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fileName matches the name of a migration file, like 0002_add_users.sql.
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.sql$`)
+
+// Migration is a single parsed migration file.
+type Migration struct {
+	// Version is the number at the start of the file name, like 2 in 0002_add_users.sql.
+	Version int
+	// Name is the description in the file name, like add_users in 0002_add_users.sql.
+	Name string
+	// File is the name of the file in the directory.
+	File string
+	// Checksum is the hex encoded SHA-256 of the file content, to detect migrations that were changed
+	// after they have been applied.
+	Checksum string
+	// Result are the parsed statements of the file.
+	Result *ddl.ParseResult
+}
+
+// FileNameError signifies that an SQL file is not named like a migration, e.g. 0001_init.sql.
+type FileNameError struct {
+	File string
+}
+
+func (e FileNameError) Error() string {
+	return fmt.Sprintf("%s is not named like a migration, e.g. 0001_init.sql", e.File)
+}
+
+// DuplicateError signifies that more than one file has the same version.
+type DuplicateError struct {
+	Version int
+	Files   []string
+}
+
+func (e DuplicateError) Error() string {
+	return fmt.Sprintf("version %d is used by more than one migration: %s", e.Version, strings.Join(e.Files, ", "))
+}
+
+// GapError signifies that a version is missing. Versions start at 1 and have no gaps.
+type GapError struct {
+	// Version is the missing version.
+	Version int
+	// Next is the file of the first version after the missing one.
+	Next string
+}
+
+func (e GapError) Error() string {
+	return fmt.Sprintf("version %d is missing before %s", e.Version, e.Next)
+}
+
+// ParseError signifies that a migration file cannot be parsed.
+type ParseError struct {
+	File string
+	Err  error
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("%s: %v", e.File, e.Err)
+}
+
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
+
+// LoadDir loads the migrations in the given directory, like Load.
+func LoadDir(dir string) ([]Migration, error) {
+	return Load(os.DirFS(dir))
+}
+
+// Load reads and parses the .sql files in the root of the file system, ordered by their version.
+// Other files and directories are ignored. Returns a FileNameError, DuplicateError, GapError or
+// ParseError, if the files do not form a valid sequence of migrations.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, FileNameError{entry.Name()}
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, FileNameError{entry.Name()}
+		}
+
+		migrations = append(migrations, Migration{Version: version, Name: match[2], File: entry.Name()})
+	}
+
+	sort.SliceStable(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	if err := validate(migrations); err != nil {
+		return nil, err
+	}
+
+	for i := range migrations {
+		sql, err := fs.ReadFile(fsys, migrations[i].File)
+		if err != nil {
+			return nil, err
+		}
+
+		checksum := sha256.Sum256(sql)
+		migrations[i].Checksum = hex.EncodeToString(checksum[:])
+
+		migrations[i].Result, err = mysql.Parse(string(sql))
+		if err != nil {
+			return nil, ParseError{File: migrations[i].File, Err: err}
+		}
+	}
+
+	return migrations, nil
+}
+
+// validate checks, that the sorted migrations have the versions 1 to N.
+func validate(migrations []Migration) error {
+	for i, migration := range migrations {
+		if i > 0 && migrations[i-1].Version == migration.Version {
+			duplicate := DuplicateError{Version: migration.Version}
+			for _, other := range migrations {
+				if other.Version == migration.Version {
+					duplicate.Files = append(duplicate.Files, other.File)
+				}
+			}
+
+			return duplicate
+		}
+	}
+
+	expected := 1
+	for _, migration := range migrations {
+		if migration.Version != expected {
+			return GapError{Version: expected, Next: migration.File}
+		}
+
+		expected++
+	}
+
+	return nil
+}
+
+// Replay applies the migrations in order. If a statement fails, a ddl.ApplyError names
+// the file and line of the migration and the statement.
+func Replay(migrations []Migration) (*ddl.Schema, error) {
+	schema := &ddl.Schema{}
+
+	for _, migration := range migrations {
+		if err := schema.Apply(migration.File, migration.Result); err != nil {
+			return nil, err
+		}
+	}
+
+	return schema, nil
+}
+
+// LoadSchema loads the migrations of a directory and replays them into the final schema.
+func LoadSchema(dir string) (*ddl.Schema, []Migration, error) {
+	migrations, err := LoadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schema, err := Replay(migrations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return schema, migrations, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2021 The Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate_test
+
+import (
+	"errors"
+	"github.com/golangee/sql/ddl"
+	"github.com/golangee/sql/migrate"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadSchema(t *testing.T) {
+	schema, migrations, err := migrate.LoadSchema("testdata/shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var files []string
+	for _, migration := range migrations {
+		files = append(files, migration.File)
+	}
+
+	expected := []string{"0001_init.sql", "0002_add_orders.sql", "0003_add_email.sql"}
+	if len(files) != len(expected) || files[0] != expected[0] || files[1] != expected[1] || files[2] != expected[2] {
+		t.Fatalf("Expected the migrations %v, but got %v", expected, files)
+	}
+
+	if migrations[2].Version != 3 || migrations[2].Name != "add_email" {
+		t.Fatalf("Unexpected version or name: %d %s", migrations[2].Version, migrations[2].Name)
+	}
+
+	if checksum := "fd8bbcbfc6643353b9af6324ccee61b69edd8cd39f3a81b43ba0417990135af9"; migrations[0].Checksum != checksum {
+		t.Fatalf("Expected checksum %s, but got %s", checksum, migrations[0].Checksum)
+	}
+
+	customer := schema.Table("Customer")
+	if customer == nil || customer.Column("Email") == nil || schema.Table("Order") == nil {
+		t.Fatalf("Expected the tables of all migrations, but got %v", schema.Tables)
+	}
+
+	if _, ok := customer.Index("Email"); !ok {
+		t.Fatal("Expected the index of the last migration")
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	sql := &fstest.MapFile{Data: []byte("CREATE TABLE Customer (Id INT);")}
+
+	tests := []struct {
+		name  string
+		fsys  fstest.MapFS
+		check func(err error) bool
+	}{
+		{
+			name: "gap",
+			fsys: fstest.MapFS{"0001_init.sql": sql, "0003_orders.sql": sql},
+			check: func(err error) bool {
+				var gap migrate.GapError
+				return errors.As(err, &gap) && gap.Version == 2 && gap.Next == "0003_orders.sql"
+			},
+		},
+		{
+			name: "missing first version",
+			fsys: fstest.MapFS{"0002_init.sql": sql},
+			check: func(err error) bool {
+				var gap migrate.GapError
+				return errors.As(err, &gap) && gap.Version == 1
+			},
+		},
+		{
+			name: "duplicate",
+			fsys: fstest.MapFS{"0001_init.sql": sql, "1_init.sql": sql, "0002_orders.sql": sql},
+			check: func(err error) bool {
+				var duplicate migrate.DuplicateError
+				return errors.As(err, &duplicate) && duplicate.Version == 1 && len(duplicate.Files) == 2
+			},
+		},
+		{
+			name: "file name",
+			fsys: fstest.MapFS{"init.sql": sql},
+			check: func(err error) bool {
+				var name migrate.FileNameError
+				return errors.As(err, &name) && name.File == "init.sql"
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := migrate.Load(test.fsys); !test.check(err) {
+				t.Fatalf("Unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestReplay_ApplyError(t *testing.T) {
+	migrations, err := migrate.Load(fstest.MapFS{
+		"0001_init.sql":    {Data: []byte("CREATE TABLE Customer (Id INT);")},
+		"0002_cleanup.sql": {Data: []byte("ALTER TABLE Customer ADD COLUMN Name TEXT;\n\nALTER TABLE Customer DROP COLUMN Mail;")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = migrate.Replay(migrations)
+
+	var applyErr ddl.ApplyError
+	if !errors.As(err, &applyErr) || applyErr.File != "0002_cleanup.sql" || applyErr.Statement.Line != 3 {
+		t.Fatalf("Expected an ApplyError in line 3 of the second migration, but got %v", err)
+	}
+
+	var notFound ddl.DropErrorNotFound
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected a DropErrorNotFound, but got %v", err)
+	}
+
+	if expected := "0002_cleanup.sql:3: ALTER TABLE Customer: "; err.Error()[:len(expected)] != expected {
+		t.Fatalf("Unexpected message %s", err)
+	}
+}
@@ -0,0 +1,4 @@
+CREATE TABLE Customer (
+  Id INT NOT NULL PRIMARY KEY,
+  Name VARCHAR(100) NOT NULL
+);
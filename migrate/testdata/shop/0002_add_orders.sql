@@ -0,0 +1,5 @@
+CREATE TABLE `Order` (
+  Id INT NOT NULL PRIMARY KEY,
+  CustomerId INT NOT NULL,
+  CONSTRAINT OrderCustomer FOREIGN KEY (CustomerId) REFERENCES Customer (Id)
+);
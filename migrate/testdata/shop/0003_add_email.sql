@@ -0,0 +1,2 @@
+ALTER TABLE Customer ADD COLUMN Email VARCHAR(255) AFTER Id;
+CREATE UNIQUE INDEX Email ON Customer (Email);
@@ -18,11 +18,50 @@ import (
 	"fmt"
 	"github.com/golangee/sql/ddl"
 	"sort"
+	"strings"
 )
 
+// Databases renders CREATE DATABASE statements sorted by name.
+func Databases(databases []ddl.Database) string {
+	sort.Slice(databases, func(i, j int) bool {
+		return databases[i].Name < databases[j].Name
+	})
+
+	result := ""
+
+	for _, database := range databases {
+		result += Database(database)
+	}
+
+	return result
+}
+
+func Database(database ddl.Database) string {
+	result := "CREATE DATABASE"
+	if database.IfNotExists {
+		result += " IF NOT EXISTS"
+	}
+
+	result += fmt.Sprintf(" `%s`", database.Name)
+
+	if database.CharacterSet != nil {
+		result += " CHARACTER SET " + *database.CharacterSet
+	}
+
+	if database.Collate != nil {
+		result += " COLLATE " + *database.Collate
+	}
+
+	return result + ";"
+}
+
 func Tables(tables []ddl.Table) string {
-	// Sort tables by name
+	// Sort tables by schema and name
 	sort.Slice(tables, func(i, j int) bool {
+		if tables[i].Schema != tables[j].Schema {
+			return tables[i].Schema < tables[j].Schema
+		}
+
 		return tables[i].Name < tables[j].Name
 	})
 
@@ -40,8 +79,21 @@ func Table(table ddl.Table) string {
 	if table.IfNotExists {
 		result += " IF NOT EXISTS"
 	}
+
+	// PRIMARY KEY and UNIQUE may be declared on a column or on the table.
+	// Always use the table level form, so that both normalize to the same SQL.
+	columns, primaryKey, uniques := liftColumnConstraints(table)
+
 	// Assemble column declarations and constraints as the statements body.
-	body := Columns(table.Columns)
+	body := Columns(columns)
+	if primaryKey != nil {
+		body += "," + PrimaryKey(*primaryKey)
+	}
+
+	if len(uniques) > 0 {
+		body += "," + UniqueConstraints(uniques)
+	}
+
 	if len(table.ForeignKeys) > 0 {
 		body += "," + ForeignKeys(table.ForeignKeys)
 	}
@@ -50,11 +102,226 @@ func Table(table ddl.Table) string {
 		body += "," + Keys(table.Keys)
 	}
 
-	result += fmt.Sprintf(" `%s` (%s);", table.Name, body)
+	if len(table.Checks) > 0 {
+		body += "," + Checks(table.Checks)
+	}
+
+	result += fmt.Sprintf(" %s (%s)%s", qualifiedName(table.Schema, table.Name), body, TableOptions(table.Options))
+
+	if table.Partitioning != nil {
+		result += " " + Partitioning(*table.Partitioning)
+	}
+
+	result += ";"
 
 	return result
 }
 
+// TableOptions renders the options alphabetically. Each option is prefixed by a space.
+func TableOptions(options ddl.TableOptions) string {
+	result := ""
+
+	if options.AutoIncrement != nil {
+		result += " AUTO_INCREMENT=" + *options.AutoIncrement
+	}
+
+	if options.CharacterSet != nil {
+		result += " CHARACTER SET=" + *options.CharacterSet
+	}
+
+	if options.Collate != nil {
+		result += " COLLATE=" + *options.Collate
+	}
+
+	if options.Comment != nil {
+		result += " COMMENT=" + quoteString(*options.Comment)
+	}
+
+	if options.Engine != nil {
+		result += " ENGINE=" + *options.Engine
+	}
+
+	if options.RowFormat != nil {
+		result += " ROW_FORMAT=" + *options.RowFormat
+	}
+
+	others := append([]string(nil), options.Others...)
+	sort.Strings(others)
+
+	for _, other := range others {
+		result += " " + other
+	}
+
+	return result
+}
+
+// Partitioning renders a PARTITION BY clause. The partitions keep their order, because
+// the bounds of RANGE partitions must be increasing.
+func Partitioning(partitioning ddl.Partitioning) string {
+	result := "PARTITION BY " + PartitionFunction(partitioning.Function)
+	if partitioning.Count != nil {
+		result += fmt.Sprintf(" PARTITIONS %d", *partitioning.Count)
+	}
+
+	if partitioning.Subpartitioning != nil {
+		result += " SUBPARTITION BY " + PartitionFunction(*partitioning.Subpartitioning)
+		if partitioning.SubpartitionCount != nil {
+			result += fmt.Sprintf(" SUBPARTITIONS %d", *partitioning.SubpartitionCount)
+		}
+	}
+
+	if len(partitioning.Partitions) > 0 {
+		var partitions []string
+		for _, partition := range partitioning.Partitions {
+			partitions = append(partitions, Partition(partition))
+		}
+
+		result += fmt.Sprintf(" (%s)", strings.Join(partitions, ","))
+	}
+
+	return result
+}
+
+// PartitionFunction renders a function like LINEAR HASH (expr) or RANGE COLUMNS (`a`,`b`).
+func PartitionFunction(function ddl.PartitionFunction) string {
+	result := ""
+	if function.Linear {
+		result += "LINEAR "
+	}
+
+	result += string(function.Method)
+
+	if function.Algorithm != nil {
+		result += fmt.Sprintf(" ALGORITHM=%d", *function.Algorithm)
+	}
+
+	// KEY always uses columns, RANGE and LIST only with the COLUMNS keyword.
+	switch {
+	case function.Method == ddl.PartitionKey:
+		result += fmt.Sprintf(" (%s)", columnNames(function.Columns))
+	case len(function.Columns) > 0:
+		result += fmt.Sprintf(" COLUMNS (%s)", columnNames(function.Columns))
+	default:
+		result += fmt.Sprintf(" (%s)", strings.TrimSpace(function.Expression))
+	}
+
+	return result
+}
+
+func Partition(partition ddl.Partition) string {
+	result := fmt.Sprintf("PARTITION `%s`", partition.Name)
+
+	if len(partition.LessThan) > 0 {
+		result += fmt.Sprintf(" VALUES LESS THAN (%s)", strings.Join(partition.LessThan, ","))
+	}
+
+	if len(partition.In) > 0 {
+		result += fmt.Sprintf(" VALUES IN (%s)", strings.Join(partition.In, ","))
+	}
+
+	result += PartitionOptions(partition.Options)
+
+	if len(partition.Subpartitions) > 0 {
+		var subpartitions []string
+		for _, subpartition := range partition.Subpartitions {
+			subpartitions = append(subpartitions,
+				fmt.Sprintf("SUBPARTITION `%s`%s", subpartition.Name, PartitionOptions(subpartition.Options)))
+		}
+
+		result += fmt.Sprintf(" (%s)", strings.Join(subpartitions, ","))
+	}
+
+	return result
+}
+
+// PartitionOptions renders the options alphabetically. Each option is prefixed by a space.
+func PartitionOptions(options ddl.PartitionOptions) string {
+	result := ""
+
+	if options.Comment != nil {
+		result += " COMMENT=" + quoteString(*options.Comment)
+	}
+
+	if options.DataDirectory != nil {
+		result += " DATA DIRECTORY=" + quoteString(*options.DataDirectory)
+	}
+
+	if options.Engine != nil {
+		result += " ENGINE=" + *options.Engine
+	}
+
+	if options.IndexDirectory != nil {
+		result += " INDEX DIRECTORY=" + quoteString(*options.IndexDirectory)
+	}
+
+	if options.MaxRows != nil {
+		result += fmt.Sprintf(" MAX_ROWS=%d", *options.MaxRows)
+	}
+
+	if options.MinRows != nil {
+		result += fmt.Sprintf(" MIN_ROWS=%d", *options.MinRows)
+	}
+
+	if options.NodeGroup != nil {
+		result += fmt.Sprintf(" NODEGROUP=`%s`", *options.NodeGroup)
+	}
+
+	if options.Tablespace != nil {
+		result += fmt.Sprintf(" TABLESPACE=`%s`", *options.Tablespace)
+	}
+
+	return result
+}
+
+// StripVolatile returns copies of the tables without options that depend on the data in
+// the tables instead of the schema, like AUTO_INCREMENT=1234 in the output of mysqldump.
+func StripVolatile(tables []ddl.Table) []ddl.Table {
+	stripped := make([]ddl.Table, 0, len(tables))
+
+	for _, table := range tables {
+		table.Options.AutoIncrement = nil
+		stripped = append(stripped, table)
+	}
+
+	return stripped
+}
+
+// liftColumnConstraints moves column level PRIMARY KEY and UNIQUE constraints into table level constraints.
+// Returns copies, so the given table is not modified.
+func liftColumnConstraints(table ddl.Table) ([]ddl.Column, *ddl.PrimaryKeyConstraint, []ddl.UniqueConstraint) {
+	var primaryKey *ddl.PrimaryKeyConstraint
+	if table.PrimaryKey != nil {
+		primaryKey = &ddl.PrimaryKeyConstraint{
+			Name:    table.PrimaryKey.Name,
+			Parts:   append([]ddl.IndexPart(nil), table.PrimaryKey.Parts...),
+			Options: table.PrimaryKey.Options,
+		}
+	}
+
+	uniques := append([]ddl.UniqueConstraint(nil), table.UniqueConstraints...)
+	columns := make([]ddl.Column, 0, len(table.Columns))
+
+	for _, column := range table.Columns {
+		if column.PrimaryKey {
+			if primaryKey == nil {
+				primaryKey = &ddl.PrimaryKeyConstraint{}
+			}
+
+			primaryKey.Parts = append(primaryKey.Parts, ddl.IndexPart{Column: column.Name})
+			column.PrimaryKey = false
+		}
+
+		if column.Unique {
+			uniques = append(uniques, ddl.UniqueConstraint{Parts: []ddl.IndexPart{{Column: column.Name}}})
+			column.Unique = false
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, primaryKey, uniques
+}
+
 func Columns(columns []ddl.Column) string {
 	// Sort columns by name
 	sort.Slice(columns, func(i, j int) bool {
@@ -75,16 +342,45 @@ func Columns(columns []ddl.Column) string {
 }
 
 func Column(column ddl.Column) string {
+	// The data type includes its character set and collation.
 	result := fmt.Sprintf("`%s` %s", column.Name, column.Type)
 
+	// Like MySQL, put the generation expression right after the type.
+	if column.Generated != nil {
+		result += fmt.Sprintf(" GENERATED ALWAYS AS (%s) %s",
+			strings.TrimSpace(column.Generated.Expression), column.Generated.Storage)
+	}
+
 	// Append constraints alphabetically
 
+	if column.AutoIncrement {
+		result += " AUTO_INCREMENT"
+	}
+
+	for _, check := range sortChecks(column.Checks) {
+		result += " " + Check(check)
+	}
+
+	if column.Comment != nil {
+		result += " COMMENT " + quoteString(*column.Comment)
+	}
+
 	if column.Default != nil {
 		result += " DEFAULT " + *column.Default
 	}
 
+	if column.Invisible {
+		result += " INVISIBLE"
+	}
+
 	if column.NotNull {
 		result += " NOT NULL"
+	} else if column.Null {
+		result += " NULL"
+	}
+
+	if column.OnUpdate != nil {
+		result += " ON UPDATE " + *column.OnUpdate
 	}
 
 	if column.PrimaryKey {
@@ -98,12 +394,52 @@ func Column(column ddl.Column) string {
 	return result
 }
 
+func PrimaryKey(key ddl.PrimaryKeyConstraint) string {
+	result := ""
+	if key.Name != nil {
+		result += fmt.Sprintf("CONSTRAINT `%s` ", *key.Name)
+	}
+
+	return result + fmt.Sprintf("PRIMARY KEY (%s)%s", IndexParts(key.Parts), IndexOptions(key.Options))
+}
+
+func UniqueConstraints(uniques []ddl.UniqueConstraint) string {
+	// Sort constraints by name then by the columns they apply to.
+	sort.Slice(uniques, func(i, j int) bool {
+		keyI := fmt.Sprintf("%s.%s", nilString(uniques[i].Name), IndexParts(uniques[i].Parts))
+		keyJ := fmt.Sprintf("%s.%s", nilString(uniques[j].Name), IndexParts(uniques[j].Parts))
+
+		return keyI < keyJ
+	})
+
+	result := ""
+
+	for i, unique := range uniques {
+		if i > 0 {
+			result += ","
+		}
+
+		result += UniqueConstraint(unique)
+	}
+
+	return result
+}
+
+func UniqueConstraint(unique ddl.UniqueConstraint) string {
+	result := "UNIQUE KEY"
+	if unique.Name != nil {
+		result += fmt.Sprintf(" `%s`", *unique.Name)
+	}
+
+	return result + fmt.Sprintf(" (%s)%s", IndexParts(unique.Parts), IndexOptions(unique.Options))
+}
+
 func ForeignKeys(keys []ddl.ForeignKeyConstraint) string {
 	// Sort keys by constraint name then by the column they apply to.
 	// This is achieved by building a string for comparison that has the format 'constraint.column'
 	sort.Slice(keys, func(i, j int) bool {
-		keyI := fmt.Sprintf("%s.%s", nilString(keys[i].Name), keys[i].Column)
-		keyJ := fmt.Sprintf("%s.%s", nilString(keys[j].Name), keys[j].Column)
+		keyI := fmt.Sprintf("%s.%s", nilString(keys[i].Name), strings.Join(keys[i].Columns, ","))
+		keyJ := fmt.Sprintf("%s.%s", nilString(keys[j].Name), strings.Join(keys[j].Columns, ","))
 
 		return keyI < keyJ
 	})
@@ -124,10 +460,27 @@ func ForeignKeys(keys []ddl.ForeignKeyConstraint) string {
 func ForeignKey(key ddl.ForeignKeyConstraint) string {
 	result := ""
 	if key.Name != nil {
-		result += fmt.Sprintf("CONSTRAINT %s ", *key.Name)
+		result += fmt.Sprintf("CONSTRAINT `%s` ", *key.Name)
+	}
+
+	result += fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s",
+		columnNames(key.Columns), qualifiedName(key.ReferenceSchema, key.ReferenceTable))
+	if len(key.ReferenceColumns) > 0 {
+		result += fmt.Sprintf("(%s)", columnNames(key.ReferenceColumns))
+	}
+
+	if key.Match != "" {
+		result += " MATCH " + string(key.Match)
+	}
+
+	// The grammar allows both orders of the actions, but we always use the same one.
+	if key.OnDelete != "" {
+		result += " ON DELETE " + string(key.OnDelete)
 	}
 
-	result += fmt.Sprintf("FOREIGN KEY (`%s`) REFERENCES `%s`(`%s`)", key.Column, key.ReferenceTable, key.ReferenceColumn)
+	if key.OnUpdate != "" {
+		result += " ON UPDATE " + string(key.OnUpdate)
+	}
 
 	return result
 }
@@ -136,8 +489,8 @@ func Keys(keys []ddl.Key) string {
 	// Sort keys by constraint name then by the column they apply to.
 	// This is achieved by building a string for comparison that has the format 'constraint.column'
 	sort.Slice(keys, func(i, j int) bool {
-		keyI := fmt.Sprintf("%s.%s", nilString(keys[i].Name), keys[i].OnColumn)
-		keyJ := fmt.Sprintf("%s.%s", nilString(keys[j].Name), keys[j].OnColumn)
+		keyI := fmt.Sprintf("%s.%s.%s", nilString(keys[i].Name), keys[i].Kind, IndexParts(keys[i].Parts))
+		keyJ := fmt.Sprintf("%s.%s.%s", nilString(keys[j].Name), keys[j].Kind, IndexParts(keys[j].Parts))
 
 		return keyI < keyJ
 	})
@@ -157,15 +510,293 @@ func Keys(keys []ddl.Key) string {
 
 func Key(key ddl.Key) string {
 	result := "KEY"
+	if key.Kind != "" {
+		result = string(key.Kind) + " KEY"
+	}
+
 	if key.Name != nil {
 		result += fmt.Sprintf(" `%s`", *key.Name)
 	}
 
-	result += fmt.Sprintf("(`%s`)", key.OnColumn)
+	result += fmt.Sprintf("(%s)%s", IndexParts(key.Parts), IndexOptions(key.Options))
 
 	return result
 }
 
+// IndexOptions renders the options alphabetically after the columns of an index.
+// Each option is prefixed by a space.
+func IndexOptions(options ddl.IndexOptions) string {
+	result := ""
+
+	if options.Comment != nil {
+		result += " COMMENT " + quoteString(*options.Comment)
+	}
+
+	if options.Invisible {
+		result += " INVISIBLE"
+	}
+
+	if options.KeyBlockSize != nil {
+		result += " KEY_BLOCK_SIZE=" + *options.KeyBlockSize
+	}
+
+	if options.Type != "" {
+		result += " USING " + string(options.Type)
+	}
+
+	if options.Parser != nil {
+		result += fmt.Sprintf(" WITH PARSER `%s`", *options.Parser)
+	}
+
+	return result
+}
+
+// IndexParts renders the columns of an index in their given order, e.g. `a`,`b`(20) DESC.
+func IndexParts(parts []ddl.IndexPart) string {
+	rendered := make([]string, 0, len(parts))
+
+	for _, part := range parts {
+		result := fmt.Sprintf("`%s`", part.Column)
+		if part.Length > 0 {
+			result += fmt.Sprintf("(%d)", part.Length)
+		}
+
+		if part.Descending {
+			result += " DESC"
+		}
+
+		rendered = append(rendered, result)
+	}
+
+	return strings.Join(rendered, ",")
+}
+
+func Checks(checks []ddl.CheckConstraint) string {
+	result := ""
+
+	for i, check := range sortChecks(checks) {
+		if i > 0 {
+			result += ","
+		}
+
+		result += Check(check)
+	}
+
+	return result
+}
+
+func Check(check ddl.CheckConstraint) string {
+	result := ""
+	if check.Name != nil {
+		result += fmt.Sprintf("CONSTRAINT `%s` ", *check.Name)
+	}
+
+	result += fmt.Sprintf("CHECK (%s)", strings.TrimSpace(check.Expression))
+	if check.NotEnforced {
+		result += " NOT ENFORCED"
+	}
+
+	return result
+}
+
+// Sort checks by constraint name then by their expression.
+// Returns a sorted copy, because column checks belong to a column that must not be modified.
+func sortChecks(checks []ddl.CheckConstraint) []ddl.CheckConstraint {
+	sorted := append([]ddl.CheckConstraint(nil), checks...)
+	sort.Slice(sorted, func(i, j int) bool {
+		keyI := fmt.Sprintf("%s.%s", nilString(sorted[i].Name), sorted[i].Expression)
+		keyJ := fmt.Sprintf("%s.%s", nilString(sorted[j].Name), sorted[j].Expression)
+
+		return keyI < keyJ
+	})
+
+	return sorted
+}
+
+func Views(views []ddl.View) string {
+	// Sort views by schema and name
+	sort.Slice(views, func(i, j int) bool {
+		if views[i].Schema != views[j].Schema {
+			return views[i].Schema < views[j].Schema
+		}
+
+		return views[i].Name < views[j].Name
+	})
+
+	result := ""
+
+	for _, view := range views {
+		result += View(view)
+	}
+
+	return result
+}
+
+func View(view ddl.View) string {
+	result := "CREATE"
+	if view.OrReplace {
+		result += " OR REPLACE"
+	}
+
+	if view.Algorithm != "" {
+		result += " ALGORITHM=" + string(view.Algorithm)
+	}
+
+	if view.Definer != nil {
+		result += " DEFINER=" + *view.Definer
+	}
+
+	if view.Security != "" {
+		result += " SQL SECURITY " + string(view.Security)
+	}
+
+	result += " VIEW " + qualifiedName(view.Schema, view.Name)
+
+	if len(view.Columns) > 0 {
+		result += fmt.Sprintf(" (%s)", columnNames(view.Columns))
+	}
+
+	result += " AS " + strings.TrimSpace(view.Definition)
+
+	if view.CheckOption != "" {
+		result += fmt.Sprintf(" WITH %s CHECK OPTION", view.CheckOption)
+	}
+
+	return result + ";"
+}
+
+// Triggers renders all triggers in a single DELIMITER block, because their bodies may contain semicolons.
+func Triggers(triggers []ddl.Trigger) string {
+	// No sorting here, as triggers for the same event run in the order of their creation.
+	var statements []string
+	for _, trigger := range triggers {
+		statements = append(statements, Trigger(trigger))
+	}
+
+	return delimited(statements)
+}
+
+// Trigger renders a CREATE TRIGGER statement without its terminating delimiter.
+func Trigger(trigger ddl.Trigger) string {
+	result := "CREATE"
+	if trigger.Definer != nil {
+		result += " DEFINER=" + *trigger.Definer
+	}
+
+	result += fmt.Sprintf(" TRIGGER %s %s %s ON %s FOR EACH ROW", qualifiedName(trigger.Schema, trigger.Name),
+		trigger.Timing, trigger.Event, qualifiedName(trigger.Schema, trigger.Table))
+
+	if trigger.Order != nil {
+		result += fmt.Sprintf(" %s `%s`", trigger.Order.Position, trigger.Order.Trigger)
+	}
+
+	return result + " " + strings.TrimSpace(trigger.Body)
+}
+
+// Routines renders all procedures and functions in a single DELIMITER block,
+// because their bodies may contain semicolons.
+func Routines(routines []ddl.Routine) string {
+	// Sort routines by kind, schema and name, procedures and functions have separate namespaces.
+	sort.Slice(routines, func(i, j int) bool {
+		if routines[i].Kind != routines[j].Kind {
+			return routines[i].Kind < routines[j].Kind
+		}
+
+		if routines[i].Schema != routines[j].Schema {
+			return routines[i].Schema < routines[j].Schema
+		}
+
+		return routines[i].Name < routines[j].Name
+	})
+
+	var statements []string
+	for _, routine := range routines {
+		statements = append(statements, Routine(routine))
+	}
+
+	return delimited(statements)
+}
+
+// Routine renders a CREATE PROCEDURE or CREATE FUNCTION statement without its terminating delimiter.
+func Routine(routine ddl.Routine) string {
+	result := "CREATE"
+	if routine.Definer != nil {
+		result += " DEFINER=" + *routine.Definer
+	}
+
+	var params []string
+
+	for _, param := range routine.Parameters {
+		// Parameters without direction are IN parameters, so write it out for procedures.
+		direction := param.Direction
+		if direction == "" && routine.Kind == ddl.RoutineProcedure {
+			direction = ddl.ParameterIn
+		}
+
+		if direction != "" {
+			params = append(params, fmt.Sprintf("%s `%s` %s", direction, param.Name, param.Type))
+		} else {
+			params = append(params, fmt.Sprintf("`%s` %s", param.Name, param.Type))
+		}
+	}
+
+	result += fmt.Sprintf(" %s %s(%s)", routine.Kind, qualifiedName(routine.Schema, routine.Name), strings.Join(params, ","))
+
+	if routine.Returns != nil {
+		result += " RETURNS " + routine.Returns.String()
+	}
+
+	// Render the characteristics alphabetically.
+	if routine.Comment != nil {
+		result += " COMMENT " + quoteString(*routine.Comment)
+	}
+
+	if routine.DataAccess != "" {
+		result += " " + string(routine.DataAccess)
+	}
+
+	if routine.Deterministic {
+		result += " DETERMINISTIC"
+	}
+
+	if routine.Security != "" {
+		result += " SQL SECURITY " + string(routine.Security)
+	}
+
+	return result + " " + strings.TrimSpace(routine.Body)
+}
+
+// delimited wraps statements in DELIMITER commands, so that the mysql client does not end them
+// at the first semicolon. The delimiter is chosen to not occur in any of the statements.
+// The block starts on a new line, because DELIMITER is only recognized at the beginning of a line.
+func delimited(statements []string) string {
+	if len(statements) == 0 {
+		return ""
+	}
+
+	delimiter := "$$"
+	for i := 0; containsAny(statements, delimiter); i++ {
+		delimiter = strings.Repeat("$", i+3)
+	}
+
+	result := "\nDELIMITER " + delimiter + "\n"
+	for _, statement := range statements {
+		result += statement + delimiter + "\n"
+	}
+
+	return result + "DELIMITER ;\n"
+}
+
+func containsAny(statements []string, s string) bool {
+	for _, statement := range statements {
+		if strings.Contains(statement, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func AlterStatements(alterStatements []ddl.AlterStatement) string {
 	// No sorting or anything is allowed here, as that would change the meaning!
 	result := ""
@@ -183,41 +814,213 @@ func AlterTableStatement(alterStatement ddl.AlterStatement) string {
 		return AlterAddColumn(stmt)
 	case ddl.AlterDropColumn:
 		return AlterDropColumn(stmt)
+	case ddl.AlterModifyColumn:
+		return AlterModifyColumn(stmt)
+	case ddl.AlterChangeColumn:
+		return AlterChangeColumn(stmt)
+	case ddl.AlterRenameColumn:
+		return AlterRenameColumn(stmt)
 	case ddl.AlterAddIndex:
 		return AlterAddIndex(stmt)
 	case ddl.AlterDropIndex:
 		return AlterDropIndex(stmt)
+	case ddl.AlterAddPrimaryKey:
+		return AlterAddPrimaryKey(stmt)
+	case ddl.AlterDropPrimaryKey:
+		return AlterDropPrimaryKey(stmt)
+	case ddl.AlterAddForeignKey:
+		return AlterAddForeignKey(stmt)
+	case ddl.AlterDropForeignKey:
+		return AlterDropForeignKey(stmt)
+	case ddl.AlterAddCheck:
+		return AlterAddCheck(stmt)
+	case ddl.AlterDropConstraint:
+		return AlterDropConstraint(stmt)
+	case ddl.AlterTableOptions:
+		return AlterTableOptions(stmt)
 	default:
 		return "not implemented"
 	}
 }
 
 func AlterAddColumn(add ddl.AlterAddColumn) string {
-	result := fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN %s", add.Table, Column(add.Column))
-	if add.First {
-		result += " FIRST"
-	} else if add.After != nil {
-		result += fmt.Sprintf(" AFTER `%s`", *add.After)
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s%s;", qualifiedName(add.Schema, add.Table), Column(add.Column),
+		columnPosition(add.First, add.After))
+}
+
+func AlterModifyColumn(modify ddl.AlterModifyColumn) string {
+	return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s%s;", qualifiedName(modify.Schema, modify.Table),
+		Column(modify.Column), columnPosition(modify.First, modify.After))
+}
+
+func AlterChangeColumn(change ddl.AlterChangeColumn) string {
+	return fmt.Sprintf("ALTER TABLE %s CHANGE COLUMN `%s` %s%s;", qualifiedName(change.Schema, change.Table),
+		change.OldName, Column(change.Column), columnPosition(change.First, change.After))
+}
+
+func AlterRenameColumn(rename ddl.AlterRenameColumn) string {
+	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN `%s` TO `%s`;", qualifiedName(rename.Schema, rename.Table),
+		rename.OldName, rename.NewName)
+}
+
+// columnPosition renders the optional FIRST or AFTER clause of a column.
+func columnPosition(first bool, after *string) string {
+	if first {
+		return " FIRST"
 	}
 
-	return result + ";"
+	if after != nil {
+		return fmt.Sprintf(" AFTER `%s`", *after)
+	}
+
+	return ""
 }
 
 func AlterDropColumn(drop ddl.AlterDropColumn) string {
-	return fmt.Sprintf("ALTER TABLE `%s` DROP COLUMN `%s`;", drop.Table, drop.Column)
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN `%s`;", qualifiedName(drop.Schema, drop.Table), drop.Column)
 }
 
 func AlterAddIndex(index ddl.AlterAddIndex) string {
-	pre := "CREATE INDEX"
+	kind := "INDEX"
 	if index.Unique {
-		pre = "CREATE UNIQUE INDEX"
+		kind = "UNIQUE INDEX"
+	} else if index.Kind != "" {
+		kind = fmt.Sprintf("%s INDEX", index.Kind)
+	}
+
+	// CREATE INDEX requires a name.
+	if index.Name == "" {
+		return fmt.Sprintf("ALTER TABLE %s ADD %s (%s)%s;", qualifiedName(index.Schema, index.Table), kind,
+			IndexParts(index.Parts), IndexOptions(index.Options))
 	}
 
-	return fmt.Sprintf("%s `%s` ON `%s`(`%s`);", pre, index.Name, index.Table, index.Column)
+	return fmt.Sprintf("CREATE %s `%s` ON %s(%s)%s;", kind, index.Name, qualifiedName(index.Schema, index.Table),
+		IndexParts(index.Parts), IndexOptions(index.Options))
 }
 
 func AlterDropIndex(drop ddl.AlterDropIndex) string {
-	return fmt.Sprintf("ALTER TABLE `%s` DROP INDEX `%s`;", drop.Table, drop.Index)
+	return fmt.Sprintf("ALTER TABLE %s DROP INDEX `%s`;", qualifiedName(drop.Schema, drop.Table), drop.Index)
+}
+
+func AlterAddPrimaryKey(add ddl.AlterAddPrimaryKey) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;", qualifiedName(add.Schema, add.Table), PrimaryKey(add.PrimaryKey))
+}
+
+func AlterDropPrimaryKey(drop ddl.AlterDropPrimaryKey) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY;", qualifiedName(drop.Schema, drop.Table))
+}
+
+func AlterAddForeignKey(add ddl.AlterAddForeignKey) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;", qualifiedName(add.Schema, add.Table), ForeignKey(add.ForeignKey))
+}
+
+func AlterDropForeignKey(drop ddl.AlterDropForeignKey) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY `%s`;", qualifiedName(drop.Schema, drop.Table), drop.Name)
+}
+
+func AlterAddCheck(add ddl.AlterAddCheck) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;", qualifiedName(add.Schema, add.Table), Check(add.Check))
+}
+
+func AlterDropConstraint(drop ddl.AlterDropConstraint) string {
+	kind := "CONSTRAINT"
+	if drop.Check {
+		kind = "CHECK"
+	}
+
+	return fmt.Sprintf("ALTER TABLE %s DROP %s `%s`;", qualifiedName(drop.Schema, drop.Table), kind, drop.Name)
+}
+
+func AlterTableOptions(alter ddl.AlterTableOptions) string {
+	return fmt.Sprintf("ALTER TABLE %s%s;", qualifiedName(alter.Schema, alter.Table), TableOptions(alter.Options))
+}
+
+// Statements renders CREATE TABLE, ALTER TABLE and schema statements in their given order.
+func Statements(statements []ddl.Statement) string {
+	result := ""
+
+	for _, stmt := range statements {
+		switch {
+		case stmt.CreateTable != nil:
+			result += Table(*stmt.CreateTable)
+		case stmt.Alter != nil:
+			result += AlterTableStatement(stmt.Alter)
+		case stmt.Schema != nil:
+			result += SchemaStatement(stmt.Schema)
+		}
+	}
+
+	return result
+}
+
+func SchemaStatements(schemaStatements []ddl.SchemaStatement) string {
+	// Like ALTER statements, these must keep their order.
+	result := ""
+
+	for _, stmt := range schemaStatements {
+		result += SchemaStatement(stmt)
+	}
+
+	return result
+}
+
+func SchemaStatement(schemaStatement ddl.SchemaStatement) string {
+	switch stmt := schemaStatement.(type) {
+	case ddl.RenameTable:
+		return RenameTable(stmt)
+	case ddl.DropTable:
+		return DropTable(stmt)
+	case ddl.TruncateTable:
+		return TruncateTable(stmt)
+	default:
+		return "not implemented"
+	}
+}
+
+func RenameTable(rename ddl.RenameTable) string {
+	return fmt.Sprintf("RENAME TABLE %s TO %s;", qualifiedName(rename.Schema, rename.Table),
+		qualifiedName(rename.NewSchema, rename.NewTable))
+}
+
+func DropTable(drop ddl.DropTable) string {
+	ifExists := ""
+	if drop.IfExists {
+		ifExists = "IF EXISTS "
+	}
+
+	return fmt.Sprintf("DROP TABLE %s%s;", ifExists, qualifiedName(drop.Schema, drop.Table))
+}
+
+func TruncateTable(truncate ddl.TruncateTable) string {
+	return fmt.Sprintf("TRUNCATE TABLE %s;", qualifiedName(truncate.Schema, truncate.Table))
+}
+
+// qualifiedName quotes a name like `shop`.`orders`. The schema is left out, if it is unknown.
+func qualifiedName(schema, name string) string {
+	if schema == "" {
+		return fmt.Sprintf("`%s`", name)
+	}
+
+	return fmt.Sprintf("`%s`.`%s`", schema, name)
+}
+
+// Quote a list of column names and separate them by commas, e.g. `a`,`b`.
+// The order is significant, so the names are not sorted.
+func columnNames(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, fmt.Sprintf("`%s`", name))
+	}
+
+	return strings.Join(quoted, ",")
+}
+
+// Quote a string as an SQL string literal, e.g. it's becomes 'it''s'.
+func quoteString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "'", "''")
+
+	return "'" + s + "'"
 }
 
 // Interpret nil as an empty string.
@@ -16,46 +16,308 @@ package normalize_test
 
 import (
 	"fmt"
+	"github.com/golangee/sql/ddl"
 	"github.com/golangee/sql/dialect/mysql"
 	"github.com/golangee/sql/internal"
 	"github.com/golangee/sql/normalize"
 	"io/ioutil"
+	"sort"
+	"strings"
 	"testing"
 )
 
 func TestNormalizeMusic(t *testing.T) {
-	sqlBytes, _ := ioutil.ReadFile("../testdata/music.sql")
+	testNormalizeTables(t, "music.sql")
+}
+
+func TestNormalizeComposite(t *testing.T) {
+	testNormalizeTables(t, "composite.sql")
+}
+
+func TestNormalizeShop(t *testing.T) {
+	testNormalizeTables(t, "shop.sql")
+}
+
+// testNormalizeTables parses the tables in the given file from the testdata folder, normalizes them,
+// and checks that parsing the normalized SQL again yields equal tables, and that normalizing is
+// stable: parsing and normalizing the normalized SQL again must not change it anymore.
+func testNormalizeTables(t *testing.T, fname string) {
+	t.Helper()
+
+	sqlBytes, err := ioutil.ReadFile("../dialect/mysql/testdata/" + fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	sql := string(sqlBytes)
 
 	// Assume that we have a correctly working parser.
-	// Parse the tables, normalize them, and parse the normalized SQL again.
-	// Parsing from original input should match the parsing from normalized input.
+	// The normalized form does not need to produce the same model, e.g. column level
+	// PRIMARY KEYs become table level constraints, but it must define equal tables.
+	// Normalizing sorts the columns, so compare them in a canonical order.
 	expectedResult, err := mysql.Parse(sql)
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
 
 	normalized := normalize.Tables(expectedResult.Tables)
 
 	actualResult, err := mysql.Parse(normalized)
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
 
 	if len(actualResult.Tables) != len(expectedResult.Tables) {
 		t.Fatalf("Expected %v tables, but got %v", len(expectedResult.Tables), len(actualResult.Tables))
 	}
 
-	// Compare outputs
-	for i := 0; i < len(expectedResult.Tables); i++ {
-		actual := actualResult.Tables[i]
-		expected := expectedResult.Tables[i]
-		internal.DiffCompare(t, actual, expected, fmt.Sprintf("table %s", actual.Name))
+	for _, expected := range expectedResult.Tables {
+		actual := findTable(actualResult.Tables, expected.Schema, expected.Name)
+		if actual == nil {
+			t.Fatalf("Expected table %s in %s", expected.Name, normalized)
+		}
+
+		if !sortColumns(*actual).Equal(sortColumns(expected)) {
+			t.Errorf("Table %s changed by normalizing", expected.Name)
+			internal.DiffCompare(t, sortColumns(*actual), sortColumns(expected), fmt.Sprintf("table %s", expected.Name))
+		}
+	}
+
+	if renormalized := normalize.Tables(actualResult.Tables); renormalized != normalized {
+		t.Fatalf("Normalizing is not stable.\nFirst: %s\nSecond: %s", normalized, renormalized)
+	}
+}
+
+func findTable(tables []ddl.Table, schema, name string) *ddl.Table {
+	for i, table := range tables {
+		if table.Schema == schema && table.Name == name {
+			return &tables[i]
+		}
+	}
+
+	return nil
+}
+
+// sortColumns returns a copy of the table with its columns sorted by name.
+func sortColumns(table ddl.Table) ddl.Table {
+	table = table.Clone()
+	sort.Slice(table.Columns, func(i, j int) bool {
+		return table.Columns[i].Name < table.Columns[j].Name
+	})
+
+	return table
+}
+
+func TestNormalizePartitions(t *testing.T) {
+	testNormalizeTables(t, "partitions.sql")
+}
+
+func TestNormalizeConstraintForms(t *testing.T) {
+	// Column level and table level constraints are equivalent and must normalize identically.
+	columnLevel := "CREATE TABLE T (A INT PRIMARY KEY, B INT UNIQUE);"
+	tableLevel := "CREATE TABLE T (A INT, B INT, UNIQUE KEY (B), PRIMARY KEY (A));"
+
+	columnResult, err := mysql.Parse(columnLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tableResult, err := mysql.Parse(tableLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "CREATE TABLE `T` (`A` INT,`B` INT,PRIMARY KEY (`A`),UNIQUE KEY (`B`));"
+
+	if actual := normalize.Tables(columnResult.Tables); actual != expected {
+		t.Errorf("Expected %s, but got %s", expected, actual)
+	}
+
+	if actual := normalize.Tables(tableResult.Tables); actual != expected {
+		t.Errorf("Expected %s, but got %s", expected, actual)
+	}
+}
+
+func TestNormalizeSchemas(t *testing.T) {
+	testNormalizeTables(t, "schemas.sql")
+
+	sqlBytes, err := ioutil.ReadFile("../dialect/mysql/testdata/schemas.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := mysql.Parse(string(sqlBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	databases := normalize.Databases(result.Databases)
+	expectedDatabases := "CREATE DATABASE `crm` COLLATE utf8mb4_bin;" +
+		"CREATE DATABASE IF NOT EXISTS `shop` CHARACTER SET utf8mb4;"
+
+	if databases != expectedDatabases {
+		t.Fatalf("Expected %s, but got %s", expectedDatabases, databases)
+	}
+
+	// Unqualified references are resolved, so that the normalized SQL does not depend on USE.
+	tables := normalize.Tables(result.Tables)
+	for _, expected := range []string{
+		"CREATE TABLE `Setting`",
+		"CREATE TABLE `shop`.`Order`",
+		"REFERENCES `crm`.`Customer`",
+		"REFERENCES `shop`.`Product`",
+	} {
+		if !strings.Contains(tables, expected) {
+			t.Errorf("Expected %s in %s", expected, tables)
+		}
+	}
+
+	alterStatements := normalize.AlterStatements(result.AlterStatements)
+	if !strings.HasPrefix(alterStatements, "ALTER TABLE `crm`.`Customer` ADD COLUMN") {
+		t.Errorf("Expected a qualified table name in %s", alterStatements)
+	}
+}
+
+func TestNormalizeViews(t *testing.T) {
+	sqlBytes, err := ioutil.ReadFile("../dialect/mysql/testdata/views.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedResult, err := mysql.Parse(string(sqlBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	normalized := normalize.Views(expectedResult.Views)
+
+	actualResult, err := mysql.Parse(normalized)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actualResult.Views) != len(expectedResult.Views) {
+		t.Fatalf("Expected %v views, but got %v", len(expectedResult.Views), len(actualResult.Views))
+	}
+
+	for i := 0; i < len(expectedResult.Views); i++ {
+		actual := actualResult.Views[i]
+		expected := expectedResult.Views[i]
+		internal.DiffCompare(t, actual, expected, fmt.Sprintf("view %s", actual.Name))
+	}
+}
+
+func TestNormalizeRoutines(t *testing.T) {
+	sqlBytes, err := ioutil.ReadFile("../dialect/mysql/testdata/routines.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedResult, err := mysql.Parse(string(sqlBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A procedure parameter without direction becomes an explicit IN parameter,
+	// so only check that the result is a fixpoint.
+	normalized := normalize.Routines(expectedResult.Routines) + normalize.Triggers(expectedResult.Triggers)
+
+	actualResult, err := mysql.Parse(normalized)
+	if err != nil {
+		t.Fatalf("%v\n%s", err, normalized)
+	}
+
+	if len(actualResult.Routines) != len(expectedResult.Routines) {
+		t.Fatalf("Expected %v routines, but got %v", len(expectedResult.Routines), len(actualResult.Routines))
+	}
+
+	if len(actualResult.Triggers) != len(expectedResult.Triggers) {
+		t.Fatalf("Expected %v triggers, but got %v", len(expectedResult.Triggers), len(actualResult.Triggers))
+	}
+
+	renormalized := normalize.Routines(actualResult.Routines) + normalize.Triggers(actualResult.Triggers)
+	if renormalized != normalized {
+		t.Fatalf("Normalizing is not stable.\nFirst: %s\nSecond: %s", normalized, renormalized)
+	}
+
+	// The body of AfterAccountUpdate contains $$, so another delimiter must be chosen.
+	if !strings.Contains(normalized, "DELIMITER $$$\n") {
+		t.Fatalf("Expected a delimiter that does not occur in any statement, but got:\n%s", normalized)
+	}
+}
+
+func TestStripVolatile(t *testing.T) {
+	result, err := mysql.Parse("CREATE TABLE T (A INT) ENGINE=InnoDB AUTO_INCREMENT=1234;")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "CREATE TABLE `T` (`A` INT) ENGINE=InnoDB;"
+	if actual := normalize.Tables(normalize.StripVolatile(result.Tables)); actual != expected {
+		t.Errorf("Expected %s, but got %s", expected, actual)
+	}
+
+	if result.Tables[0].Options.AutoIncrement == nil {
+		t.Errorf("StripVolatile must not modify the given tables")
 	}
 }
 
 func TestNormalizeAlter(t *testing.T) {
-	sqlBytes, _ := ioutil.ReadFile("../testdata/alter-user.sql")
+	testNormalizeAlter(t, "alter-user.sql")
+}
+
+func TestNormalizeAlterColumns(t *testing.T) {
+	testNormalizeAlter(t, "alter-columns.sql")
+}
+
+func TestNormalizeAlterConstraints(t *testing.T) {
+	testNormalizeAlter(t, "alter-constraints.sql")
+}
+
+func TestNormalizeAlterOptions(t *testing.T) {
+	testNormalizeAlter(t, "alter-options.sql")
+}
+
+func TestNormalizeIndexes(t *testing.T) {
+	testNormalizeTables(t, "indexes.sql")
+	testNormalizeAlter(t, "indexes.sql")
+
+	result, err := mysql.Parse("CREATE TABLE T (A INT, B TEXT, PRIMARY KEY (A) USING HASH, " +
+		"FULLTEXT INDEX (B) COMMENT 'text' INVISIBLE);")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The index type is always rendered as an option after the columns.
+	expected := "CREATE TABLE `T` (`A` INT,`B` TEXT,PRIMARY KEY (`A`) USING HASH," +
+		"FULLTEXT KEY(`B`) COMMENT 'text' INVISIBLE);"
+	if actual := normalize.Tables(result.Tables); actual != expected {
+		t.Fatalf("Expected %s, but got %s", expected, actual)
+	}
+}
+
+func TestNormalizeSchemaStatements(t *testing.T) {
+	sqlBytes, _ := ioutil.ReadFile("../dialect/mysql/testdata/schema-statements.sql")
+
+	expectedResult, err := mysql.Parse(string(sqlBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	normalized := normalize.SchemaStatements(expectedResult.SchemaStatements)
+
+	actualResult, err := mysql.Parse(normalized)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	internal.DiffCompare(t, actualResult.SchemaStatements, expectedResult.SchemaStatements, "schema statements")
+}
+
+func testNormalizeAlter(t *testing.T, fname string) {
+	t.Helper()
+
+	sqlBytes, _ := ioutil.ReadFile("../dialect/mysql/testdata/" + fname)
 	sql := string(sqlBytes)
 
 	// Assume that we have a correctly working parser.